@@ -0,0 +1,313 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// NullableArray is the generic array stored in the PostgreSQL array format,
+// elements are encoded by the codec C
+//
+//	type BoolArray = gosql.NullableArray[bool, gosql.BoolCodec]
+type NullableArray[T any, C ArrayElementCodec[T]] []T
+
+// Value implements the driver.Valuer interface, []T field
+func (f NullableArray[T, C]) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	buff, err := encodeArray[T, C](PostgresArrayFormat, f)
+	if err != nil {
+		return nil, err
+	}
+	return buff.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, []T field
+func (f *NullableArray[T, C]) Scan(value any) (err error) {
+	*f, err = decodeArray[T, C](value, PostgresArrayFormat)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NullableArray[T, C]) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]T(f))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NullableArray[T, C]) UnmarshalJSON(b []byte) error {
+	var list []T
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
+// DecodeValue implements the gocast.Decoder
+func (f *NullableArray[T, C]) DecodeValue(v any) error {
+	switch val := v.(type) {
+	case nil:
+		*f = nil
+	case []T:
+		*f = val
+	case NullableArray[T, C]:
+		*f = val
+	case Array[T, C]:
+		*f = NullableArray[T, C](val)
+	case []byte, string:
+		return f.Scan(v)
+	default:
+		return ErrInvalidDecodeValue
+	}
+	return nil
+}
+
+// Len of array
+func (f NullableArray[T, C]) Len() int { return len(f) }
+
+// Filter current array and create filtered copy
+func (f NullableArray[T, C]) Filter(fn func(v T) bool) NullableArray[T, C] {
+	resp := make(NullableArray[T, C], 0, len(f))
+	for _, v := range f {
+		if fn(v) {
+			resp = append(resp, v)
+		}
+	}
+	return resp
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Array is the generic not nullable array stored in the PostgreSQL array format,
+// elements are encoded by the codec C
+//
+//	type TimeArray = gosql.Array[time.Time, gosql.TimeCodec]
+type Array[T any, C ArrayElementCodec[T]] []T
+
+// Value implements the driver.Valuer interface, []T field
+func (f Array[T, C]) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return NullableArray[T, C](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field
+func (f *Array[T, C]) Scan(value any) error {
+	if value == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableArray[T, C])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f Array[T, C]) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return NullableArray[T, C](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *Array[T, C]) UnmarshalJSON(b []byte) error {
+	if b == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableArray[T, C])(f).UnmarshalJSON(b)
+}
+
+// DecodeValue implements the gocast.Decoder
+func (f *Array[T, C]) DecodeValue(v any) error {
+	if v == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableArray[T, C])(f).DecodeValue(v)
+}
+
+// Len of array
+func (f Array[T, C]) Len() int { return len(f) }
+
+// Filter current array and create filtered copy
+func (f Array[T, C]) Filter(fn func(v T) bool) Array[T, C] {
+	return Array[T, C](NullableArray[T, C](f).Filter(fn))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// NullableOrderedArray is the generic nullable array of the sorted values
+type NullableOrderedArray[T constraints.Ordered, C ArrayElementCodec[T]] []T
+
+// Value implements the driver.Valuer interface, []T field
+func (f NullableOrderedArray[T, C]) Value() (driver.Value, error) {
+	return NullableArray[T, C](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field
+func (f *NullableOrderedArray[T, C]) Scan(value any) error {
+	return (*NullableArray[T, C])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NullableOrderedArray[T, C]) MarshalJSON() ([]byte, error) {
+	return NullableArray[T, C](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NullableOrderedArray[T, C]) UnmarshalJSON(b []byte) error {
+	return (*NullableArray[T, C])(f).UnmarshalJSON(b)
+}
+
+// Sort array
+func (f NullableOrderedArray[T, C]) Sort() NullableOrderedArray[T, C] {
+	sort.Sort(f)
+	return f
+}
+
+// Len of array
+func (s NullableOrderedArray[T, C]) Len() int           { return len(s) }
+func (s NullableOrderedArray[T, C]) Less(i, j int) bool { return s[i] < s[j] }
+func (s NullableOrderedArray[T, C]) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// IndexOf array value
+func (f NullableOrderedArray[T, C]) IndexOf(v T) int {
+	i := sort.Search(f.Len(), func(i int) bool { return f[i] >= v })
+	if i >= 0 && i < f.Len() && f[i] == v {
+		return i
+	}
+	return -1
+}
+
+// OneOf value in array
+func (f NullableOrderedArray[T, C]) OneOf(vals []T) bool {
+	if len(f) < 1 || len(vals) < 1 {
+		return false
+	}
+	for _, v := range vals {
+		if f.IndexOf(v) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// OrderedArray is the generic not nullable array of the sorted values
+type OrderedArray[T constraints.Ordered, C ArrayElementCodec[T]] []T
+
+// Value implements the driver.Valuer interface, []T field
+func (f OrderedArray[T, C]) Value() (driver.Value, error) {
+	return Array[T, C](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field
+func (f *OrderedArray[T, C]) Scan(value any) error {
+	return (*Array[T, C])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f OrderedArray[T, C]) MarshalJSON() ([]byte, error) {
+	return Array[T, C](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *OrderedArray[T, C]) UnmarshalJSON(b []byte) error {
+	return (*Array[T, C])(f).UnmarshalJSON(b)
+}
+
+// Sort array
+func (f OrderedArray[T, C]) Sort() OrderedArray[T, C] {
+	sort.Sort(f)
+	return f
+}
+
+// Len of array
+func (s OrderedArray[T, C]) Len() int           { return len(s) }
+func (s OrderedArray[T, C]) Less(i, j int) bool { return s[i] < s[j] }
+func (s OrderedArray[T, C]) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// IndexOf array value
+func (f OrderedArray[T, C]) IndexOf(v T) int {
+	return NullableOrderedArray[T, C](f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f OrderedArray[T, C]) OneOf(vals []T) bool {
+	return NullableOrderedArray[T, C](f).OneOf(vals)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// decodeArray decodes the one-dimensional array literal using the element codec
+func decodeArray[T any, C ArrayElementCodec[T]](value any, format ArrayFormat) ([]T, error) {
+	var src string
+	switch v := value.(type) {
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	case []T:
+		return v, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, ErrInvalidScan
+	}
+	elems, err := parseFlatArrayLiteral(src, format)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		codec C
+		arr   = make([]T, 0, len(elems))
+	)
+	for _, elem := range elems {
+		if elem.null {
+			return nil, ErrNullValueNotAllowed
+		}
+		v, err := codec.DecodeElement(elem.value)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+// encodeArray encodes the one-dimensional array literal using the element codec
+func encodeArray[T any, C ArrayElementCodec[T]](format ArrayFormat, arr []T) (*bytes.Buffer, error) {
+	var (
+		buff       bytes.Buffer
+		codec      C
+		quoter, _  = any(codec).(ArrayElementQuoter)
+		literal, _ = any(codec).(ArrayElementLiteral)
+		isLiteral  = literal != nil && literal.LiteralElement()
+	)
+	buff.WriteByte(format.Begin)
+	for i, v := range arr {
+		if i > 0 {
+			buff.WriteByte(format.Delimiter)
+		}
+		s, err := codec.EncodeElement(v)
+		if err != nil {
+			return nil, err
+		}
+		if quoter != nil && quoter.QuoteElement(s) {
+			format.appendQuoted(&buff, s)
+		} else {
+			format.appendElement(&buff, s, isLiteral)
+		}
+	}
+	buff.WriteByte(format.End)
+	return &buff, nil
+}
@@ -0,0 +1,242 @@
+package gosql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// PostgreSQL OIDs of the array elements in the binary format
+const (
+	oidName    uint32 = 19
+	oidInt8    uint32 = 20
+	oidInt2    uint32 = 21
+	oidInt4    uint32 = 23
+	oidText    uint32 = 25
+	oidFloat4  uint32 = 700
+	oidFloat8  uint32 = 701
+	oidBPChar  uint32 = 1042
+	oidVarchar uint32 = 1043
+)
+
+// ArrayNumberEncodeBinary encodes one-dimensional array in the PostgreSQL binary format.
+//
+// The element type is chosen by the size of T: int2 for 8-bit and int16 values,
+// int4 for uint16 and int32, int8 for other integers, float4 and float8 for floats.
+func ArrayNumberEncodeBinary[T Number](arr []T) ([]byte, error) {
+	return ArrayNumberAppendBinary(nil, arr)
+}
+
+// ArrayNumberAppendBinary appends one-dimensional array in the PostgreSQL binary format to the buffer
+func ArrayNumberAppendBinary[T Number](buf []byte, arr []T) ([]byte, error) {
+	var (
+		oid, size   = numberElementOID[T]()
+		zero, one T = 0, 1
+		unsigned    = zero-one > zero
+	)
+	buf = appendBinaryArrayHeader(buf, oid, len(arr))
+	for _, v := range arr {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(size))
+		switch oid {
+		case oidInt2:
+			buf = binary.BigEndian.AppendUint16(buf, uint16(int16(v)))
+		case oidInt4:
+			buf = binary.BigEndian.AppendUint32(buf, uint32(int32(v)))
+		case oidInt8:
+			if unsigned && uint64(v) > math.MaxInt64 {
+				return nil, fmt.Errorf("%w: value %v out of bigint range", ErrInvalidBinaryArray, v)
+			}
+			buf = binary.BigEndian.AppendUint64(buf, uint64(int64(v)))
+		case oidFloat4:
+			buf = binary.BigEndian.AppendUint32(buf, math.Float32bits(float32(v)))
+		default:
+			buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(float64(v)))
+		}
+	}
+	return buf, nil
+}
+
+// ArrayNumberDecodeBinary decodes one-dimensional array of int2, int4, int8, float4 or float8
+// elements in the PostgreSQL binary format, values out of the range of T are rejected
+func ArrayNumberDecodeBinary[T Number](data []byte) (result []T, err error) {
+	err = decodeBinaryArray(data, func(count int) {
+		result = make([]T, 0, count)
+	}, func(oid uint32, elem []byte) error {
+		v, err := decodeBinaryNumber[T](oid, elem)
+		if err == nil {
+			result = append(result, v)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ArrayStringEncodeBinary encodes one-dimensional array of text elements in the PostgreSQL binary format
+func ArrayStringEncodeBinary(arr []string) []byte {
+	return ArrayStringAppendBinary(nil, arr)
+}
+
+// ArrayStringAppendBinary appends one-dimensional array of text elements
+// in the PostgreSQL binary format to the buffer
+func ArrayStringAppendBinary(buf []byte, arr []string) []byte {
+	buf = appendBinaryArrayHeader(buf, oidText, len(arr))
+	for _, v := range arr {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v)))
+		buf = append(buf, v...)
+	}
+	return buf
+}
+
+// ArrayStringDecodeBinary decodes one-dimensional array of text, varchar, bpchar or name
+// elements in the PostgreSQL binary format
+func ArrayStringDecodeBinary(data []byte) (result []string, err error) {
+	err = decodeBinaryArray(data, func(count int) {
+		result = make([]string, 0, count)
+	}, func(oid uint32, elem []byte) error {
+		switch oid {
+		case oidText, oidVarchar, oidBPChar, oidName:
+			result = append(result, string(elem))
+			return nil
+		}
+		return fmt.Errorf("%w: unsupported element type %d", ErrInvalidBinaryArray, oid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// numberElementOID returns the element type OID and the size of the encoded element,
+// the kind of T is used to support named types like `type Score float64`
+func numberElementOID[T Number]() (uint32, int) {
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Int8, reflect.Uint8, reflect.Int16:
+		return oidInt2, 2
+	case reflect.Uint16, reflect.Int32:
+		return oidInt4, 4
+	case reflect.Float32:
+		return oidFloat4, 4
+	case reflect.Float64:
+		return oidFloat8, 8
+	default:
+		return oidInt8, 8
+	}
+}
+
+// isFloatNumber returns true if the kind of T is float32 or float64
+func isFloatNumber[T Number]() bool {
+	kind := reflect.TypeOf(T(0)).Kind()
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// appendBinaryArrayHeader writes the header of one-dimensional array without NULL elements,
+// the empty array has no dimensions
+func appendBinaryArrayHeader(buf []byte, oid uint32, count int) []byte {
+	if count == 0 {
+		buf = binary.BigEndian.AppendUint32(buf, 0) // ndim
+		buf = binary.BigEndian.AppendUint32(buf, 0) // has nulls
+		return binary.BigEndian.AppendUint32(buf, oid)
+	}
+	buf = binary.BigEndian.AppendUint32(buf, 1)
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, oid)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(count))
+	return binary.BigEndian.AppendUint32(buf, 1) // lower bound
+}
+
+// decodeBinaryArray reads the header of one-dimensional array and calls fn for each element,
+// NULL elements are not allowed
+func decodeBinaryArray(data []byte, prepare func(count int), fn func(oid uint32, elem []byte) error) error {
+	if len(data) < 12 {
+		return fmt.Errorf("%w: header is too short", ErrInvalidBinaryArray)
+	}
+	ndim := binary.BigEndian.Uint32(data)
+	oid := binary.BigEndian.Uint32(data[8:])
+	data = data[12:]
+	count := 0
+	switch ndim {
+	case 0:
+	case 1:
+		if len(data) < 8 {
+			return fmt.Errorf("%w: dimension is too short", ErrInvalidBinaryArray)
+		}
+		count = int(int32(binary.BigEndian.Uint32(data)))
+		if count < 0 || count > len(data)/4 {
+			return fmt.Errorf("%w: invalid array length %d", ErrInvalidBinaryArray, count)
+		}
+		data = data[8:]
+	default:
+		return fmt.Errorf("%w: expected one-dimensional array, got %d dimensions", ErrInvalidBinaryArray, ndim)
+	}
+	prepare(count)
+	for i := 0; i < count; i++ {
+		if len(data) < 4 {
+			return fmt.Errorf("%w: element %d is too short", ErrInvalidBinaryArray, i)
+		}
+		size := int32(binary.BigEndian.Uint32(data))
+		data = data[4:]
+		if size < 0 {
+			return ErrNullValueNotAllowed
+		}
+		if int(size) > len(data) {
+			return fmt.Errorf("%w: element %d is too short", ErrInvalidBinaryArray, i)
+		}
+		if err := fn(oid, data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: unexpected trailing data", ErrInvalidBinaryArray)
+	}
+	return nil
+}
+
+// decodeBinaryNumber decodes the element and checks that it fits into T
+func decodeBinaryNumber[T Number](oid uint32, elem []byte) (T, error) {
+	var (
+		i       int64
+		f       float64
+		isFloat bool
+	)
+	switch {
+	case oid == oidInt2 && len(elem) == 2:
+		i = int64(int16(binary.BigEndian.Uint16(elem)))
+	case oid == oidInt4 && len(elem) == 4:
+		i = int64(int32(binary.BigEndian.Uint32(elem)))
+	case oid == oidInt8 && len(elem) == 8:
+		i = int64(binary.BigEndian.Uint64(elem))
+	case oid == oidFloat4 && len(elem) == 4:
+		f, isFloat = float64(math.Float32frombits(binary.BigEndian.Uint32(elem))), true
+	case oid == oidFloat8 && len(elem) == 8:
+		f, isFloat = math.Float64frombits(binary.BigEndian.Uint64(elem)), true
+	case oid == oidInt2, oid == oidInt4, oid == oidInt8, oid == oidFloat4, oid == oidFloat8:
+		return 0, fmt.Errorf("%w: invalid element size %d", ErrInvalidBinaryArray, len(elem))
+	default:
+		return 0, fmt.Errorf("%w: unsupported element type %d", ErrInvalidBinaryArray, oid)
+	}
+	if isFloatNumber[T]() {
+		if isFloat {
+			return T(f), nil
+		}
+		return T(i), nil
+	}
+	if isFloat {
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%w: value %v is not an integer", ErrInvalidBinaryArray, f)
+		}
+		i = int64(f)
+	}
+	if v := T(i); int64(v) != i || (v < 0) != (i < 0) {
+		return 0, fmt.Errorf("%w: value %d out of range", ErrInvalidBinaryArray, i)
+	}
+	return T(i), nil
+}
@@ -0,0 +1,233 @@
+package gosql
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// binaryFixture decodes the hex dump of the PostgreSQL binary value, spaces are ignored
+func binaryFixture(s string) []byte {
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+var (
+	// ARRAY[1,2]::int8[]
+	int8ArrayFixture = binaryFixture("00000001 00000000 00000014 00000002 00000001" +
+		"00000008 0000000000000001 00000008 0000000000000002")
+	// ARRAY[-1,300]::int4[]
+	int4ArrayFixture = binaryFixture("00000001 00000000 00000017 00000002 00000001" +
+		"00000004 ffffffff 00000004 0000012c")
+	// ARRAY[7]::int2[]
+	int2ArrayFixture = binaryFixture("00000001 00000000 00000015 00000001 00000001 00000002 0007")
+	// ARRAY[1.5]::float4[]
+	float4ArrayFixture = binaryFixture("00000001 00000000 000002bc 00000001 00000001 00000004 3fc00000")
+	// ARRAY[1.5,-2]::float8[]
+	float8ArrayFixture = binaryFixture("00000001 00000000 000002bd 00000002 00000001" +
+		"00000008 3ff8000000000000 00000008 c000000000000000")
+	// ARRAY['a','b c','']::text[]
+	textArrayFixture = binaryFixture("00000001 00000000 00000019 00000003 00000001" +
+		"00000001 61 00000003 622063 00000000")
+	// '{}'::int8[]
+	emptyInt8ArrayFixture = binaryFixture("00000000 00000000 00000014")
+)
+
+func TestArrayNumberBinary(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		data, err := ArrayNumberEncodeBinary([]int64{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int8ArrayFixture, data)
+
+		data, err = ArrayNumberEncodeBinary([]int32{-1, 300})
+		assert.NoError(t, err)
+		assert.Equal(t, int4ArrayFixture, data)
+
+		data, err = ArrayNumberEncodeBinary([]uint8{7})
+		assert.NoError(t, err)
+		assert.Equal(t, int2ArrayFixture, data)
+
+		data, err = ArrayNumberEncodeBinary([]float32{1.5})
+		assert.NoError(t, err)
+		assert.Equal(t, float4ArrayFixture, data)
+
+		data, err = ArrayNumberEncodeBinary([]float64{1.5, -2})
+		assert.NoError(t, err)
+		assert.Equal(t, float8ArrayFixture, data)
+
+		data, err = ArrayNumberEncodeBinary[int64](nil)
+		assert.NoError(t, err)
+		assert.Equal(t, emptyInt8ArrayFixture, data)
+
+		_, err = ArrayNumberEncodeBinary([]uint64{math.MaxUint64})
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+
+		data, err = ArrayNumberAppendBinary([]byte{0xff}, []int64{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, append([]byte{0xff}, int8ArrayFixture...), data)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		i64, err := ArrayNumberDecodeBinary[int64](int8ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, i64)
+
+		i64, err = ArrayNumberDecodeBinary[int64](int4ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{-1, 300}, i64)
+
+		f64, err := ArrayNumberDecodeBinary[float64](int2ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{7}, f64)
+
+		f32, err := ArrayNumberDecodeBinary[float32](float8ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []float32{1.5, -2}, f32)
+
+		i64, err = ArrayNumberDecodeBinary[int64](emptyInt8ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{}, i64)
+
+		// '[0:1]={1,2}'::int8[]
+		i64, err = ArrayNumberDecodeBinary[int64](binaryFixture("00000001 00000000 00000014 00000002 00000000" +
+			"00000008 0000000000000001 00000008 0000000000000002"))
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, i64)
+	})
+
+	t.Run("decode:errors", func(t *testing.T) {
+		_, err := ArrayNumberDecodeBinary[int8](int4ArrayFixture)
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray, "300 is out of int8 range")
+		_, err = ArrayNumberDecodeBinary[uint32](int4ArrayFixture)
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray, "-1 is out of uint32 range")
+		_, err = ArrayNumberDecodeBinary[int64](float8ArrayFixture)
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray, "1.5 is not an integer")
+		_, err = ArrayNumberDecodeBinary[int64](textArrayFixture)
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+		_, err = ArrayNumberDecodeBinary[int64](int8ArrayFixture[:len(int8ArrayFixture)-1])
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+		_, err = ArrayNumberDecodeBinary[int64](append(int8ArrayFixture[:len(int8ArrayFixture):len(int8ArrayFixture)], 0))
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+		_, err = ArrayNumberDecodeBinary[int64](int8ArrayFixture[:8])
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+
+		// ARRAY[1,NULL]::int8[]
+		_, err = ArrayNumberDecodeBinary[int64](binaryFixture("00000001 00000001 00000014 00000002 00000001" +
+			"00000008 0000000000000001 ffffffff"))
+		assert.ErrorIs(t, err, ErrNullValueNotAllowed)
+
+		// ARRAY[[1],[2]]::int8[]
+		_, err = ArrayNumberDecodeBinary[int64](binaryFixture("00000002 00000000 00000014 00000002 00000001 00000001 00000001" +
+			"00000008 0000000000000001 00000008 0000000000000002"))
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+	})
+
+	t.Run("named_types", func(t *testing.T) {
+		type score float64
+		type level int16
+
+		data, err := ArrayNumberEncodeBinary([]score{1.5, -2})
+		assert.NoError(t, err)
+		assert.Equal(t, float8ArrayFixture, data)
+
+		scores, err := ArrayNumberDecodeBinary[score](float8ArrayFixture)
+		assert.NoError(t, err)
+		assert.Equal(t, []score{1.5, -2}, scores)
+
+		data, err = ArrayNumberEncodeBinary([]level{7})
+		assert.NoError(t, err)
+		assert.Equal(t, int2ArrayFixture, data)
+
+		_, err = ArrayNumberDecodeBinary[level](float8ArrayFixture)
+		assert.ErrorIs(t, err, ErrInvalidBinaryArray, "1.5 is not an integer")
+	})
+}
+
+func TestArrayStringBinary(t *testing.T) {
+	assert.Equal(t, textArrayFixture, ArrayStringEncodeBinary([]string{"a", "b c", ""}))
+	assert.Equal(t, binaryFixture("00000000 00000000 00000019"), ArrayStringEncodeBinary(nil))
+
+	arr, err := ArrayStringDecodeBinary(textArrayFixture)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b c", ""}, arr)
+
+	// ARRAY['x']::varchar[]
+	arr, err = ArrayStringDecodeBinary(binaryFixture("00000001 00000000 00000413 00000001 00000001 00000001 78"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x"}, arr)
+
+	_, err = ArrayStringDecodeBinary(int8ArrayFixture)
+	assert.ErrorIs(t, err, ErrInvalidBinaryArray)
+}
+
+func TestArrayTypesBinary(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		data, err := NumberArray[int64]{1, 2}.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Equal(t, int8ArrayFixture, data)
+
+		data, err = NumberArray[int64](nil).MarshalBinary()
+		assert.NoError(t, err)
+		assert.Equal(t, emptyInt8ArrayFixture, data)
+
+		data, err = NullableNumberArray[int64](nil).MarshalBinary()
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+
+		var arr NumberArray[int64]
+		assert.NoError(t, arr.UnmarshalBinary(int8ArrayFixture))
+		assert.Equal(t, NumberArray[int64]{1, 2}, arr)
+		assert.ErrorIs(t, arr.UnmarshalBinary(nil), ErrNullValueNotAllowed)
+
+		var ordered OrderedNumberArray[int32]
+		assert.NoError(t, ordered.UnmarshalBinary(int4ArrayFixture))
+		assert.Equal(t, OrderedNumberArray[int32]{-1, 300}, ordered)
+
+		nullable := NullableOrderedNumberArray[int64]{1}
+		assert.NoError(t, nullable.UnmarshalBinary(nil))
+		assert.Nil(t, nullable)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		data, err := StringArray{"a", "b c", ""}.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Equal(t, textArrayFixture, data)
+
+		data, err = NullableStringArray(nil).MarshalBinary()
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+
+		var arr StringArray
+		assert.NoError(t, arr.UnmarshalBinary(textArrayFixture))
+		assert.Equal(t, StringArray{"a", "b c", ""}, arr)
+		assert.ErrorIs(t, arr.UnmarshalBinary(nil), ErrNullValueNotAllowed)
+
+		nullable := NullableStringArray{"x"}
+		assert.NoError(t, nullable.UnmarshalBinary(nil))
+		assert.Nil(t, nullable)
+	})
+}
+
+func BenchmarkArrayNumberEncode(b *testing.B) {
+	arr := make([]int64, 1000)
+	for i := range arr {
+		arr[i] = int64(i) * 1_000_003
+	}
+	b.Run("text", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = ArrayNumberEncode('{', '}', arr)
+		}
+	})
+	b.Run("binary", func(b *testing.B) {
+		buf := make([]byte, 0, 20+len(arr)*12)
+		for i := 0; i < b.N; i++ {
+			buf, _ = ArrayNumberAppendBinary(buf[:0], arr)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package gosql
+
+import (
+	"encoding"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ArrayElementCodec encodes and decodes the single element of the array
+// in the text format of the array literal.
+//
+// Codec is used as the type parameter of the generic arrays so it must be
+// usable as the zero value, like the empty struct.
+type ArrayElementCodec[T any] interface {
+	EncodeElement(v T) (string, error)
+	DecodeElement(s string) (T, error)
+}
+
+// ArrayElementQuoter is implemented by the codec which requires quoting of
+// the encoded element in addition to the default quoting rules
+type ArrayElementQuoter interface {
+	QuoteElement(s string) bool
+}
+
+// NumberCodec of the array element
+type NumberCodec[T Number] struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (NumberCodec[T]) EncodeElement(v T) (string, error) { return formatNumber(v), nil }
+
+// DecodeElement implements ArrayElementCodec
+func (NumberCodec[T]) DecodeElement(s string) (T, error) { return parseNumber[T](s) }
+
+// LiteralElement implements ArrayElementLiteral, numbers are never quoted
+func (NumberCodec[T]) LiteralElement() bool { return true }
+
+// StringCodec of the array element
+type StringCodec struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (StringCodec) EncodeElement(v string) (string, error) { return v, nil }
+
+// DecodeElement implements ArrayElementCodec
+func (StringCodec) DecodeElement(s string) (string, error) { return s, nil }
+
+// BoolCodec of the array element, encodes values as `t` and `f`
+type BoolCodec struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (BoolCodec) EncodeElement(v bool) (string, error) {
+	if v {
+		return "t", nil
+	}
+	return "f", nil
+}
+
+// DecodeElement implements ArrayElementCodec
+func (BoolCodec) DecodeElement(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "t", "true", "y", "yes", "on", "1":
+		return true, nil
+	case "f", "false", "n", "no", "off", "0":
+		return false, nil
+	}
+	return false, ErrInvalidDecodeValue
+}
+
+// TimeCodec of the array element, encodes values in the PostgreSQL timestamptz format
+type TimeCodec struct{}
+
+var timeCodecLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// EncodeElement implements ArrayElementCodec
+func (TimeCodec) EncodeElement(v time.Time) (string, error) {
+	return v.Format(timeCodecLayouts[0]), nil
+}
+
+// DecodeElement implements ArrayElementCodec
+func (TimeCodec) DecodeElement(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeCodecLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// CharCodec of the array element
+type CharCodec struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (CharCodec) EncodeElement(v Char) (string, error) { return string(v), nil }
+
+// DecodeElement implements ArrayElementCodec, the element must be exactly one character
+func (CharCodec) DecodeElement(s string) (Char, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == utf8.RuneError && size == 1 {
+		return Char(0), ErrInvalidDecodeValue
+	}
+	return Char(r), nil
+}
+
+// DurationCodec of the array element
+type DurationCodec struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (DurationCodec) EncodeElement(v Duration) (string, error) { return v.String(), nil }
+
+// DecodeElement implements ArrayElementCodec
+func (DurationCodec) DecodeElement(s string) (Duration, error) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Duration(v), nil
+	}
+	return ParseDuration(s)
+}
+
+// TextValue is the pointer to type which implements encoding.TextUnmarshaler
+type TextValue[T any] interface {
+	*T
+	encoding.TextUnmarshaler
+}
+
+// TextCodec of the array element for types implementing encoding.TextMarshaler
+// and encoding.TextUnmarshaler, like UUID types
+//
+//	type UUIDArray = gosql.Array[uuid.UUID, gosql.TextCodec[uuid.UUID, *uuid.UUID]]
+type TextCodec[T encoding.TextMarshaler, PT TextValue[T]] struct{}
+
+// EncodeElement implements ArrayElementCodec
+func (TextCodec[T, PT]) EncodeElement(v T) (string, error) {
+	data, err := v.MarshalText()
+	return string(data), err
+}
+
+// DecodeElement implements ArrayElementCodec
+func (TextCodec[T, PT]) DecodeElement(s string) (T, error) {
+	var v T
+	err := PT(&v).UnmarshalText([]byte(s))
+	return v, err
+}
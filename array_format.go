@@ -0,0 +1,226 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// ArrayFormat defines the text representation of the array literal
+type ArrayFormat struct {
+	Begin     byte // Begin of the array or sub-array
+	End       byte // End of the array or sub-array
+	Delimiter byte // Delimiter of the elements
+	Quote     byte // Quote character of the elements
+
+	// QuoteAlways quotes all text elements even if they don't contain special characters,
+	// the elements of literal codecs (like numbers) are never quoted
+	QuoteAlways bool
+
+	// Null is the token of the NULL element, compared case-insensitively
+	Null string
+
+	// CEscapes enables escape sequences like `\n`, `\t`, `\xHH` and `\uHHHH`,
+	// otherwise the backslash escapes any next character
+	CEscapes bool
+
+	// HexEscape is the prefix of the hex escape of control characters like `\u00` or `\x`,
+	// used only with CEscapes
+	HexEscape string
+
+	// Bounds enables the dimension decoration like `[0:1]=`
+	Bounds bool
+}
+
+// Predefined array formats
+var (
+	// PostgresArrayFormat is the PostgreSQL array literal `{a,"b c",NULL}`
+	PostgresArrayFormat = ArrayFormat{
+		Begin: '{', End: '}', Delimiter: ',', Quote: '"',
+		Null: "NULL", Bounds: true,
+	}
+
+	// JSONArrayFormat is the JSON array `["a","b c",null]`
+	JSONArrayFormat = ArrayFormat{
+		Begin: '[', End: ']', Delimiter: ',', Quote: '"',
+		QuoteAlways: true, Null: "null", CEscapes: true, HexEscape: `\u00`,
+	}
+
+	// ClickHouseArrayFormat is the ClickHouse array literal `['a','b c',NULL]`
+	ClickHouseArrayFormat = ArrayFormat{
+		Begin: '[', End: ']', Delimiter: ',', Quote: '\'',
+		QuoteAlways: true, Null: "NULL", CEscapes: true, HexEscape: `\x`,
+	}
+)
+
+// ArrayElementLiteral is implemented by the codec which elements are written
+// without quotes in any format, like numbers
+type ArrayElementLiteral interface {
+	LiteralElement() bool
+}
+
+// ArrayFormatDefinition defines the format of the FormattedArray
+type ArrayFormatDefinition interface {
+	ArrayFormat() ArrayFormat
+}
+
+// Predefined array format definitions
+type (
+	ArrayFormatPostgres   struct{}
+	ArrayFormatJSON       struct{}
+	ArrayFormatClickHouse struct{}
+)
+
+func (ArrayFormatPostgres) ArrayFormat() ArrayFormat   { return PostgresArrayFormat }
+func (ArrayFormatJSON) ArrayFormat() ArrayFormat       { return JSONArrayFormat }
+func (ArrayFormatClickHouse) ArrayFormat() ArrayFormat { return ClickHouseArrayFormat }
+
+// EncodeArray encodes the one-dimensional array in the format using the element codec,
+// the nil array is encoded as the empty array
+//
+//	s, err := gosql.EncodeArray[string, gosql.StringCodec](gosql.ClickHouseArrayFormat, []string{"a", "b"})
+func EncodeArray[T any, C ArrayElementCodec[T]](format ArrayFormat, arr []T) (string, error) {
+	buff, err := encodeArray[T, C](format, arr)
+	if err != nil {
+		return "", err
+	}
+	return buff.String(), nil
+}
+
+// DecodeArray decodes the one-dimensional array in the format using the element codec,
+// the NULL token is decoded as the nil array
+//
+//	arr, err := gosql.DecodeArray[int64, gosql.NumberCodec[int64]](gosql.JSONArrayFormat, "[1,2]")
+func DecodeArray[T any, C ArrayElementCodec[T]](format ArrayFormat, src string) ([]T, error) {
+	if strings.EqualFold(strings.TrimSpace(src), format.nullToken()) {
+		return nil, nil
+	}
+	return decodeArray[T, C](src, format)
+}
+
+// FormattedArray is the generic nullable array stored in the format defined by F,
+// elements are encoded by the codec C
+//
+//	type ClickHouseTags = gosql.FormattedArray[string, gosql.StringCodec, gosql.ArrayFormatClickHouse]
+type FormattedArray[T any, C ArrayElementCodec[T], F ArrayFormatDefinition] []T
+
+// Value implements the driver.Valuer interface, []T field
+func (f FormattedArray[T, C, F]) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	var format F
+	return EncodeArray[T, C](format.ArrayFormat(), f)
+}
+
+// Scan implements the sql.Scanner interface, []T field
+func (f *FormattedArray[T, C, F]) Scan(value any) (err error) {
+	var format F
+	*f, err = decodeArray[T, C](value, format.ArrayFormat())
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f FormattedArray[T, C, F]) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]T(f))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *FormattedArray[T, C, F]) UnmarshalJSON(b []byte) error {
+	var list []T
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
+// Len of array
+func (f FormattedArray[T, C, F]) Len() int { return len(f) }
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// postgresArrayFormatWith returns the PostgreSQL format with custom markers,
+// the dimension decoration is supported only with `{}` markers
+func postgresArrayFormatWith(begin, end, delim byte) ArrayFormat {
+	format := PostgresArrayFormat
+	format.Begin, format.End, format.Delimiter = begin, end, delim
+	format.Bounds = begin == '{'
+	return format
+}
+
+func (f ArrayFormat) nullToken() string {
+	if f.Null == "" {
+		return "NULL"
+	}
+	return f.Null
+}
+
+// appendNull writes the NULL element
+func (f ArrayFormat) appendNull(buff *bytes.Buffer) {
+	buff.WriteString(f.nullToken())
+}
+
+// appendElement writes the element value quoting it if necessary
+func (f ArrayFormat) appendElement(buff *bytes.Buffer, v string, literal bool) {
+	if literal || !f.QuoteAlways && !f.needsQuote(v) {
+		buff.WriteString(v)
+		return
+	}
+	f.appendQuoted(buff, v)
+}
+
+// appendQuoted writes the element value in quotes
+func (f ArrayFormat) appendQuoted(buff *bytes.Buffer, v string) {
+	buff.WriteByte(f.Quote)
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case c == f.Quote || c == '\\':
+			buff.WriteByte('\\')
+		case f.CEscapes && c < 0x20:
+			switch c {
+			case '\n':
+				buff.WriteString(`\n`)
+			case '\t':
+				buff.WriteString(`\t`)
+			case '\r':
+				buff.WriteString(`\r`)
+			case '\b':
+				buff.WriteString(`\b`)
+			case '\f':
+				buff.WriteString(`\f`)
+			default:
+				buff.WriteString(f.HexEscape)
+				if c < 0x10 {
+					buff.WriteByte('0')
+				}
+				buff.WriteString(strconv.FormatUint(uint64(c), 16))
+			}
+			continue
+		}
+		buff.WriteByte(c)
+	}
+	buff.WriteByte(f.Quote)
+}
+
+// needsQuote returns true if the value can't be written as is
+func (f ArrayFormat) needsQuote(v string) bool {
+	if v == "" || strings.EqualFold(v, f.nullToken()) {
+		return true
+	}
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; {
+		case c == f.Quote, c == '\\', c == f.Begin, c == f.End, c == '{', c == '}', c == f.Delimiter, isArraySpace(c):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package gosql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayFormat(t *testing.T) {
+	strs := []string{"a", "b c", `q"'\`, "line\nbreak", "", "null", "\x01"}
+	tests := []struct {
+		name    string
+		format  ArrayFormat
+		strings string
+		numbers string
+	}{
+		{"postgres", PostgresArrayFormat, `{a,"b c","q\"'\\","line` + "\n" + `break","","null",` + "\x01}", "{1,-2,3.5}"},
+		{"json", JSONArrayFormat, `["a","b c","q\"'\\","line\nbreak","","null","\u0001"]`, "[1,-2,3.5]"},
+		{"clickhouse", ClickHouseArrayFormat, `['a','b c','q"\'\\','line\nbreak','','null','\x01']`, "[1,-2,3.5]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := EncodeArray[string, StringCodec](test.format, strs)
+			assert.NoError(t, err)
+			assert.Equal(t, test.strings, s)
+
+			arr, err := DecodeArray[string, StringCodec](test.format, s)
+			assert.NoError(t, err)
+			assert.Equal(t, strs, arr)
+
+			s, err = EncodeArray[float64, NumberCodec[float64]](test.format, []float64{1, -2, 3.5})
+			assert.NoError(t, err)
+			assert.Equal(t, test.numbers, s)
+
+			nums, err := DecodeArray[float64, NumberCodec[float64]](test.format, s)
+			assert.NoError(t, err)
+			assert.Equal(t, []float64{1, -2, 3.5}, nums)
+
+			empty, err := EncodeArray[string, StringCodec](test.format, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, string([]byte{test.format.Begin, test.format.End}), empty)
+
+			arr, err = DecodeArray[string, StringCodec](test.format, test.format.Null)
+			assert.NoError(t, err)
+			assert.Nil(t, arr)
+		})
+	}
+
+	t.Run("decode:escapes", func(t *testing.T) {
+		arr, err := DecodeArray[string, StringCodec](JSONArrayFormat, `["\u00e9\ud83d\ude00", "tab\there"]`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"é😀", "tab\there"}, arr)
+
+		arr, err = DecodeArray[string, StringCodec](ClickHouseArrayFormat, `['it''s', 'a\x41']`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"it's", "aA"}, arr)
+
+		_, err = DecodeArray[string, StringCodec](JSONArrayFormat, `["\u00"]`)
+		assert.ErrorIs(t, err, ErrInvalidArrayLiteral)
+		_, err = DecodeArray[string, StringCodec](JSONArrayFormat, `["a",null]`)
+		assert.ErrorIs(t, err, ErrNullValueNotAllowed)
+		_, err = DecodeArray[string, StringCodec](JSONArrayFormat, `[0:1]={a,b}`)
+		assert.Error(t, err)
+	})
+}
+
+func TestFormattedArray(t *testing.T) {
+	type ClickHouseTags = FormattedArray[string, StringCodec, ArrayFormatClickHouse]
+	type JSONIDs = FormattedArray[int64, NumberCodec[int64], ArrayFormatJSON]
+
+	v, err := ClickHouseTags{"a", "b"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "['a','b']", v)
+
+	v, err = JSONIDs{1, 2}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", v)
+
+	v, err = JSONIDs(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	var tags ClickHouseTags
+	assert.NoError(t, tags.Scan([]byte("['x', 'y z']")))
+	assert.Equal(t, ClickHouseTags{"x", "y z"}, tags)
+	assert.NoError(t, tags.Scan(nil))
+	assert.Nil(t, tags)
+
+	var ids JSONIDs
+	assert.NoError(t, ids.Scan("[3, 4]"))
+	assert.Equal(t, 2, ids.Len())
+
+	data, err := ids.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "[3,4]", string(data))
+	assert.NoError(t, ids.UnmarshalJSON([]byte("[5]")))
+	assert.Equal(t, JSONIDs{5}, ids)
+
+	type PostgresFlags = FormattedArray[bool, BoolCodec, ArrayFormatPostgres]
+	v, err = PostgresFlags{true, false}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{t,f}", v)
+}
@@ -0,0 +1,415 @@
+package gosql
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ArrayDimension describes one dimension of the array
+type ArrayDimension struct {
+	Length     int `json:"length"`
+	LowerBound int `json:"lower_bound"`
+}
+
+// arrayElement is a single element of the parsed array literal
+type arrayElement struct {
+	value  string
+	quoted bool
+	null   bool
+}
+
+// parseArrayLiteral parses array in the text format, by default it's the PostgreSQL format
+//
+// The format is described in https://www.postgresql.org/docs/current/arrays.html#ARRAYS-IO
+// Elements can be quoted, any character can be escaped by backslash
+// and the unquoted NULL token (case-insensitive) is the NULL element.
+// Multidimensional arrays are returned as the flat list of elements in
+// row-major order with the dimensions, optionally prefixed by the dimension
+// decoration like `[0:1][1:2]=` which defines the lower bounds.
+// For compatibility with values written by the previous encoder the doubled
+// quote inside of the quoted element is interpreted as an escaped quote.
+func parseArrayLiteral(src string, format ArrayFormat) ([]ArrayDimension, []arrayElement, error) {
+	p := arrayLiteralParser{src: src, ArrayFormat: format, leafDepth: -1}
+	return p.parse()
+}
+
+// parseFlatArrayLiteral parses one-dimensional array literal
+func parseFlatArrayLiteral(src string, format ArrayFormat) ([]arrayElement, error) {
+	dims, elems, err := parseArrayLiteral(src, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(dims) > 1 {
+		return nil, fmt.Errorf("%w: expected one-dimensional array, got %d dimensions", ErrInvalidArrayLiteral, len(dims))
+	}
+	return elems, nil
+}
+
+type arrayLiteralParser struct {
+	ArrayFormat
+	src string
+	pos int
+
+	lengths   []int
+	leafDepth int
+	elems     []arrayElement
+	emptySub  bool // at least one sub-array is empty like `{{}}`
+}
+
+func (p *arrayLiteralParser) parse() ([]ArrayDimension, []arrayElement, error) {
+	p.skipSpaces()
+	bounds, err := p.parseDecoration()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = p.parseLevel(0); err != nil {
+		return nil, nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return nil, nil, p.errorf("unexpected trailing data")
+	}
+	if p.emptySub && len(p.elems) > 0 {
+		return nil, nil, p.errorf("multidimensional arrays must have sub-arrays with matching dimensions")
+	}
+	if p.elems == nil {
+		p.elems = []arrayElement{}
+	}
+	var dims []ArrayDimension
+	if len(p.elems) > 0 {
+		dims = make([]ArrayDimension, len(p.lengths))
+		for i, l := range p.lengths {
+			dims[i] = ArrayDimension{Length: l, LowerBound: 1}
+		}
+	}
+	if bounds != nil {
+		if len(bounds) != len(dims) {
+			return nil, nil, p.errorf("dimension decoration does not match array dimensions")
+		}
+		for i, b := range bounds {
+			if b.Length != dims[i].Length {
+				return nil, nil, p.errorf("dimension decoration does not match array dimensions")
+			}
+			dims[i].LowerBound = b.LowerBound
+		}
+	}
+	return dims, p.elems, nil
+}
+
+// parseDecoration parses optional dimension decoration `[lower:upper]...=`
+func (p *arrayLiteralParser) parseDecoration() ([]ArrayDimension, error) {
+	if !p.Bounds || !p.consume('[') {
+		return nil, nil
+	}
+	var dims []ArrayDimension
+	for {
+		lower, upper := 1, 0
+		val, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		if p.consume(':') {
+			lower = val
+			if upper, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+		} else {
+			upper = val
+		}
+		if !p.consume(']') {
+			return nil, p.errorf("expected ']'")
+		}
+		if upper < lower-1 {
+			return nil, p.errorf("upper bound cannot be less than lower bound")
+		}
+		dims = append(dims, ArrayDimension{Length: upper - lower + 1, LowerBound: lower})
+		if !p.consume('[') {
+			break
+		}
+	}
+	p.skipSpaces()
+	if !p.consume('=') {
+		return nil, p.errorf("expected '='")
+	}
+	p.skipSpaces()
+	return dims, nil
+}
+
+func (p *arrayLiteralParser) parseInt() (int, error) {
+	start := p.pos
+	if p.pos < len(p.src) && (p.src[p.pos] == '-' || p.src[p.pos] == '+') {
+		p.pos++
+	}
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	val, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid dimension bound")
+	}
+	return val, nil
+}
+
+func (p *arrayLiteralParser) parseLevel(depth int) error {
+	if !p.consume(p.Begin) {
+		return p.errorf("expected %q", p.Begin)
+	}
+	p.skipSpaces()
+	if p.consume(p.End) {
+		// the array of empty sub-arrays is the empty array like in PostgreSQL
+		p.emptySub = p.emptySub || depth > 0
+		return nil
+	}
+	count := 0
+	for {
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == p.Begin {
+			if p.leafDepth >= 0 && depth >= p.leafDepth {
+				return p.errorf("unexpected sub-array")
+			}
+			if err := p.parseLevel(depth + 1); err != nil {
+				return err
+			}
+		} else {
+			if p.leafDepth < 0 {
+				p.leafDepth = depth
+			} else if p.leafDepth != depth {
+				return p.errorf("multidimensional arrays must have sub-arrays with matching dimensions")
+			}
+			elem, err := p.parseElement()
+			if err != nil {
+				return err
+			}
+			p.elems = append(p.elems, elem)
+		}
+		count++
+		p.skipSpaces()
+		if p.consume(p.Delimiter) {
+			continue
+		}
+		if p.consume(p.End) {
+			break
+		}
+		return p.errorf("expected %q or %q", p.Delimiter, p.End)
+	}
+	for len(p.lengths) <= depth {
+		p.lengths = append(p.lengths, -1)
+	}
+	if p.lengths[depth] < 0 {
+		p.lengths[depth] = count
+	} else if p.lengths[depth] != count {
+		return p.errorf("multidimensional arrays must have sub-arrays with matching dimensions")
+	}
+	return nil
+}
+
+func (p *arrayLiteralParser) parseElement() (arrayElement, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return arrayElement{}, p.errorf("unexpected end of input")
+	}
+	if p.src[p.pos] == p.Quote {
+		return p.parseQuoted()
+	}
+	return p.parseUnquoted()
+}
+
+func (p *arrayLiteralParser) parseQuoted() (arrayElement, error) {
+	var buf strings.Builder
+	p.pos++ // opening quote
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			if err := p.parseEscape(&buf); err != nil {
+				return arrayElement{}, err
+			}
+		case c == p.Quote && p.pos+1 < len(p.src) && p.src[p.pos+1] == p.Quote:
+			buf.WriteByte(p.Quote)
+			p.pos += 2
+		case c == p.Quote:
+			p.pos++
+			return arrayElement{value: buf.String(), quoted: true}, nil
+		default:
+			buf.WriteByte(c)
+			p.pos++
+		}
+	}
+	return arrayElement{}, p.errorf("unterminated quoted element")
+}
+
+func (p *arrayLiteralParser) parseUnquoted() (arrayElement, error) {
+	var (
+		buf      strings.Builder
+		start    = p.pos
+		escaped  = false
+		trimTail = 0 // length of the buffer without trailing unescaped spaces
+	)
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == p.Delimiter || c == p.End {
+			break
+		}
+		switch c {
+		case '\\':
+			if err := p.parseEscape(&buf); err != nil {
+				return arrayElement{}, err
+			}
+			trimTail = buf.Len()
+			escaped = true
+			continue
+		case p.Quote, p.Begin:
+			return arrayElement{}, p.errorf("unexpected %q in unquoted element", c)
+		}
+		buf.WriteByte(c)
+		if !isArraySpace(c) {
+			trimTail = buf.Len()
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return arrayElement{}, p.errorf("empty element")
+	}
+	value := buf.String()[:trimTail]
+	if !escaped && strings.EqualFold(value, p.nullToken()) {
+		return arrayElement{null: true}, nil
+	}
+	return arrayElement{value: value}, nil
+}
+
+// parseEscape decodes the escape sequence at the current position,
+// without CEscapes the backslash escapes any next character
+func (p *arrayLiteralParser) parseEscape(buf *strings.Builder) error {
+	if p.pos+1 >= len(p.src) {
+		return p.errorf("unexpected end of input after escape")
+	}
+	c := p.src[p.pos+1]
+	p.pos += 2
+	if !p.CEscapes {
+		buf.WriteByte(c)
+		return nil
+	}
+	switch c {
+	case 'n':
+		buf.WriteByte('\n')
+	case 't':
+		buf.WriteByte('\t')
+	case 'r':
+		buf.WriteByte('\r')
+	case 'b':
+		buf.WriteByte('\b')
+	case 'f':
+		buf.WriteByte('\f')
+	case '0':
+		buf.WriteByte(0)
+	case 'x':
+		v, err := p.parseHex(2)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(byte(v))
+	case 'u':
+		r, err := p.parseHex(4)
+		if err != nil {
+			return err
+		}
+		if utf16.IsSurrogate(r) && strings.HasPrefix(p.src[p.pos:], "\\u") {
+			p.pos += 2
+			r2, err := p.parseHex(4)
+			if err != nil {
+				return err
+			}
+			r = utf16.DecodeRune(r, r2)
+		}
+		buf.WriteRune(r)
+	default:
+		buf.WriteByte(c)
+	}
+	return nil
+}
+
+func (p *arrayLiteralParser) parseHex(size int) (rune, error) {
+	if p.pos+size > len(p.src) {
+		return 0, p.errorf("invalid escape sequence")
+	}
+	v, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid escape sequence")
+	}
+	p.pos += size
+	return rune(v), nil
+}
+
+func (p *arrayLiteralParser) skipSpaces() {
+	for p.pos < len(p.src) && isArraySpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *arrayLiteralParser) consume(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *arrayLiteralParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidArrayLiteral, fmt.Sprintf(format, args...), p.pos)
+}
+
+// encodeArrayLiteral writes multidimensional array with the dimension decoration
+// if any of lower bounds is not equal to 1
+func encodeArrayLiteral(buff *bytes.Buffer, dims []ArrayDimension, begin, end, delim byte, writeElem func(buff *bytes.Buffer, i int)) {
+	if len(dims) == 0 {
+		buff.WriteByte(begin)
+		buff.WriteByte(end)
+		return
+	}
+	for _, dim := range dims {
+		if dim.LowerBound != 1 {
+			for _, dim := range dims {
+				buff.WriteByte('[')
+				buff.WriteString(strconv.Itoa(dim.LowerBound))
+				buff.WriteByte(':')
+				buff.WriteString(strconv.Itoa(dim.LowerBound + dim.Length - 1))
+				buff.WriteByte(']')
+			}
+			buff.WriteByte('=')
+			break
+		}
+	}
+	offset := 0
+	var writeLevel func(depth int)
+	writeLevel = func(depth int) {
+		buff.WriteByte(begin)
+		for i := 0; i < dims[depth].Length; i++ {
+			if i > 0 {
+				buff.WriteByte(delim)
+			}
+			if depth == len(dims)-1 {
+				writeElem(buff, offset)
+				offset++
+			} else {
+				writeLevel(depth + 1)
+			}
+		}
+		buff.WriteByte(end)
+	}
+	writeLevel(0)
+}
+
+// appendArrayElement writes the element value in the PostgreSQL format quoting it if necessary
+func appendArrayElement(buff *bytes.Buffer, v string, delim byte) {
+	format := PostgresArrayFormat
+	format.Delimiter = delim
+	format.appendElement(buff, v, false)
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
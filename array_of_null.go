@@ -0,0 +1,194 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// NumberArrayOfNull is the array of numbers which can contain NULL elements
+// like `{1,NULL,3}` produced by `array_agg` over outer joins.
+// The NULL element is represented by the nil pointer and the nil array is the NULL value.
+type NumberArrayOfNull[T Number] []*T
+
+// Value implements the driver.Valuer interface, []*T field
+func (f NumberArrayOfNull[T]) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return encodeArrayOfNull(PostgresArrayFormat, []*T(f), func(buff *bytes.Buffer, v T) {
+		buff.WriteString(formatNumber(v))
+	}).String(), nil
+}
+
+// Scan implements the sql.Scanner interface, []*T field
+func (f *NumberArrayOfNull[T]) Scan(value any) (err error) {
+	*f, err = decodeArrayOfNull[T, NumberCodec[T]](value)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NumberArrayOfNull[T]) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	return encodeArrayOfNull(JSONArrayFormat, []*T(f), func(buff *bytes.Buffer, v T) {
+		buff.WriteString(formatNumber(v))
+	}).Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NumberArrayOfNull[T]) UnmarshalJSON(b []byte) error {
+	var list []*T
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
+// Len of array
+func (f NumberArrayOfNull[T]) Len() int { return len(f) }
+
+// IsNull returns true if the element is NULL
+func (f NumberArrayOfNull[T]) IsNull(i int) bool { return f[i] == nil }
+
+// ValueOr returns the element or default value if it's NULL
+func (f NumberArrayOfNull[T]) ValueOr(i int, def T) T {
+	if f[i] == nil {
+		return def
+	}
+	return *f[i]
+}
+
+// Compact returns not NULL values
+func (f NumberArrayOfNull[T]) Compact() NumberArray[T] {
+	return compactArrayOfNull([]*T(f))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// StringArrayOfNull is the array of strings which can contain NULL elements
+// like `{a,NULL,c}`. The NULL element is represented by the nil pointer
+// and the nil array is the NULL value.
+type StringArrayOfNull []*string
+
+// Value implements the driver.Valuer interface, []*string field
+func (f StringArrayOfNull) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return encodeArrayOfNull(PostgresArrayFormat, []*string(f), func(buff *bytes.Buffer, v string) {
+		appendArrayElement(buff, v, ',')
+	}).String(), nil
+}
+
+// Scan implements the sql.Scanner interface, []*string field
+func (f *StringArrayOfNull) Scan(value any) (err error) {
+	*f, err = decodeArrayOfNull[string, StringCodec](value)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f StringArrayOfNull) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]*string(f))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *StringArrayOfNull) UnmarshalJSON(b []byte) error {
+	var list []*string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
+// Len of array
+func (f StringArrayOfNull) Len() int { return len(f) }
+
+// IsNull returns true if the element is NULL
+func (f StringArrayOfNull) IsNull(i int) bool { return f[i] == nil }
+
+// ValueOr returns the element or default value if it's NULL
+func (f StringArrayOfNull) ValueOr(i int, def string) string {
+	if f[i] == nil {
+		return def
+	}
+	return *f[i]
+}
+
+// Compact returns not NULL values
+func (f StringArrayOfNull) Compact() StringArray {
+	return compactArrayOfNull([]*string(f))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+func decodeArrayOfNull[T any, C ArrayElementCodec[T]](value any) ([]*T, error) {
+	var src string
+	switch v := value.(type) {
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	case []*T:
+		return v, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, ErrInvalidScan
+	}
+	elems, err := parseFlatArrayLiteral(src, PostgresArrayFormat)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		codec C
+		arr   = make([]*T, 0, len(elems))
+	)
+	for _, elem := range elems {
+		if elem.null {
+			arr = append(arr, nil)
+			continue
+		}
+		v, err := codec.DecodeElement(elem.value)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, &v)
+	}
+	return arr, nil
+}
+
+func encodeArrayOfNull[T any](format ArrayFormat, arr []*T, writeElem func(buff *bytes.Buffer, v T)) *bytes.Buffer {
+	var buff bytes.Buffer
+	buff.WriteByte(format.Begin)
+	for i, v := range arr {
+		if i > 0 {
+			buff.WriteByte(format.Delimiter)
+		}
+		if v == nil {
+			format.appendNull(&buff)
+			continue
+		}
+		writeElem(&buff, *v)
+	}
+	buff.WriteByte(format.End)
+	return &buff
+}
+
+func compactArrayOfNull[T any](arr []*T) []T {
+	resp := make([]T, 0, len(arr))
+	for _, v := range arr {
+		if v != nil {
+			resp = append(resp, *v)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+package gosql
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberArrayOfNull(t *testing.T) {
+	var arr NumberArrayOfNull[int64]
+	if assert.NoError(t, arr.Scan("{1,NULL,3}")) {
+		assert.Equal(t, 3, arr.Len())
+		assert.False(t, arr.IsNull(0))
+		assert.True(t, arr.IsNull(1))
+		assert.Equal(t, int64(-1), arr.ValueOr(1, -1))
+		assert.Equal(t, int64(3), arr.ValueOr(2, -1))
+		assert.Equal(t, NumberArray[int64]{1, 3}, arr.Compact())
+	}
+
+	val, err := arr.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{1,NULL,3}", val)
+
+	data, err := json.Marshal(arr)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,null,3]", string(data))
+
+	var arr2 NumberArrayOfNull[int64]
+	assert.NoError(t, json.Unmarshal(data, &arr2))
+	assert.Equal(t, arr, arr2)
+
+	t.Run("format", func(t *testing.T) {
+		buff := encodeArrayOfNull(ClickHouseArrayFormat, []*int64(arr), func(buff *bytes.Buffer, v int64) {
+			buff.WriteString(formatNumber(v))
+		})
+		assert.Equal(t, "[1,NULL,3]", buff.String())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var arr NumberArrayOfNull[float64]
+		assert.NoError(t, arr.Scan(nil))
+		assert.Nil(t, arr)
+		val, err := arr.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+		data, err := json.Marshal(arr)
+		assert.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var arr NumberArrayOfNull[int]
+		assert.Error(t, arr.Scan("{1,x}"))
+		assert.ErrorIs(t, arr.Scan("{1,2"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, arr.Scan(1.5), ErrInvalidScan)
+	})
+}
+
+func TestStringArrayOfNull(t *testing.T) {
+	var arr StringArrayOfNull
+	if assert.NoError(t, arr.Scan(`{a,NULL,"NULL",""}`)) {
+		assert.Equal(t, 4, arr.Len())
+		assert.True(t, arr.IsNull(1))
+		assert.Equal(t, "NULL", arr.ValueOr(2, "-"))
+		assert.Equal(t, StringArray{"a", "NULL", ""}, arr.Compact())
+	}
+
+	val, err := arr.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{a,NULL,"NULL",""}`, val)
+
+	data, err := json.Marshal(arr)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a",null,"NULL",""]`, string(data))
+
+	var arr2 StringArrayOfNull
+	assert.NoError(t, json.Unmarshal(data, &arr2))
+	assert.Equal(t, arr, arr2)
+}
@@ -0,0 +1,131 @@
+package gosql
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type quotedCodec struct{ StringCodec }
+
+func (quotedCodec) QuoteElement(string) bool { return true }
+
+func TestArray(t *testing.T) {
+	t.Run("named_number", func(t *testing.T) {
+		type id int64
+		big := id(9007199254740993) // is not exact in float64
+
+		val, err := NumberArray[id]{big, -1}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{9007199254740993,-1}", val)
+		var arr NumberArray[id]
+		if assert.NoError(t, arr.Scan(val)) {
+			assert.Equal(t, NumberArray[id]{big, -1}, arr)
+		}
+
+		val, err = Array[id, NumberCodec[id]]{big}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{9007199254740993}", val)
+		var generic Array[id, NumberCodec[id]]
+		if assert.NoError(t, generic.Scan(val)) {
+			assert.Equal(t, Array[id, NumberCodec[id]]{big}, generic)
+		}
+
+		var nulls NumberArrayOfNull[id]
+		if assert.NoError(t, nulls.Scan("{9007199254740993,NULL}")) {
+			val, err = nulls.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, "{9007199254740993,NULL}", val)
+		}
+
+		var small Array[int8, NumberCodec[int8]]
+		assert.Error(t, small.Scan("{300}"), "out of int8 range")
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		var arr Array[bool, BoolCodec]
+		if assert.NoError(t, arr.Scan("{t,f,true,FALSE}")) {
+			assert.Equal(t, Array[bool, BoolCodec]{true, false, true, false}, arr)
+		}
+		val, err := arr.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{t,f,t,f}", val)
+		assert.Error(t, arr.Scan("{maybe}"))
+		assert.ErrorIs(t, arr.Scan(nil), ErrNullValueNotAllowed)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		var arr Array[time.Time, TimeCodec]
+		if assert.NoError(t, arr.Scan(`{"2024-01-02 03:04:05.5+00","2024-01-02T03:04:05Z"}`)) {
+			assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 5e8, time.UTC), arr[0].UTC())
+			assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), arr[1].UTC())
+		}
+		val, err := Array[time.Time, TimeCodec]{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"2024-01-02 03:04:05Z"}`, val)
+	})
+
+	t.Run("char", func(t *testing.T) {
+		var arr Array[Char, CharCodec]
+		if assert.NoError(t, arr.Scan(`{A,"✓",","}`)) {
+			assert.Equal(t, Array[Char, CharCodec]{'A', '✓', ','}, arr)
+		}
+		val, err := arr.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{A,✓,","}`, val)
+		assert.ErrorIs(t, arr.Scan(`{ab}`), ErrInvalidDecodeValue)
+		assert.ErrorIs(t, arr.Scan(`{"✓x"}`), ErrInvalidDecodeValue)
+		assert.ErrorIs(t, arr.Scan(`{""}`), ErrInvalidDecodeValue)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var arr NullableArray[net.IP, TextCodec[net.IP, *net.IP]]
+		if assert.NoError(t, arr.Scan(`{127.0.0.1,::1}`)) {
+			assert.True(t, net.IPv4(127, 0, 0, 1).Equal(arr[0]))
+			assert.True(t, net.IPv6loopback.Equal(arr[1]))
+		}
+		val, err := arr.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{127.0.0.1,::1}", val)
+		assert.NoError(t, arr.Scan(nil))
+		assert.Nil(t, arr)
+	})
+
+	t.Run("quoter", func(t *testing.T) {
+		val, err := Array[string, quotedCodec]{"a", "b"}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a","b"}`, val)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		arr := Array[Duration, DurationCodec]{Second, Minute}
+		data, err := json.Marshal(arr)
+		assert.NoError(t, err)
+		var arr2 Array[Duration, DurationCodec]
+		assert.NoError(t, json.Unmarshal(data, &arr2))
+		assert.Equal(t, arr, arr2)
+
+		data, err = json.Marshal(Array[int, NumberCodec[int]](nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(data))
+		data, err = json.Marshal(NullableArray[int, NumberCodec[int]](nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+	})
+
+	t.Run("ordered", func(t *testing.T) {
+		var arr OrderedArray[string, StringCodec]
+		assert.NoError(t, arr.Scan("{c,a,b}"))
+		arr.Sort()
+		assert.Equal(t, 1, arr.IndexOf("b"))
+		assert.Equal(t, -1, arr.IndexOf("d"))
+		assert.True(t, arr.OneOf([]string{"d", "a"}))
+
+		var narr NullableOrderedArray[Duration, DurationCodec]
+		assert.NoError(t, narr.Scan(nil))
+		assert.Nil(t, narr)
+	})
+}
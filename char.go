@@ -5,7 +5,13 @@
 
 package gosql
 
-import "database/sql/driver"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+)
 
 // Char type of field
 type Char rune
@@ -29,12 +35,14 @@ func (f Char) MarshalJSON() ([]byte, error) {
 	if f == 0 {
 		return []byte("\" \""), nil
 	}
-	return []byte{'"', byte(f), '"'}, nil
+	return json.Marshal(string(f))
 }
 
 // UnmarshalJSON implements the json.Unmarshaller
+//
+// Accepts JSON string with the single character or the number of the character code.
 func (f *Char) UnmarshalJSON(b []byte) (err error) {
-	*f, err = decodeChar(b)
+	*f, err = decodeJSONChar(b)
 	return err
 }
 
@@ -50,11 +58,11 @@ func decodeChar(value any) (Char, error) {
 	switch v := value.(type) {
 	case []byte:
 		if len(v) > 0 {
-			return Char(v[0]), nil
+			return decodeCharBytes(v), nil
 		}
 	case string:
 		if len(v) > 0 {
-			return Char(v[0]), nil
+			return decodeCharBytes([]byte(v)), nil
 		}
 	case rune:
 		return Char(v), nil
@@ -67,3 +75,34 @@ func decodeChar(value any) (Char, error) {
 	}
 	return Char(0), ErrInvalidScan
 }
+
+// decodeCharBytes returns the first UTF-8 character or the first byte
+// if the value is not valid UTF-8 string
+func decodeCharBytes(v []byte) Char {
+	r, size := utf8.DecodeRune(v)
+	if r == utf8.RuneError && size <= 1 {
+		return Char(v[0])
+	}
+	return Char(r)
+}
+
+func decodeJSONChar(b []byte) (Char, error) {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0:
+		return Char(0), ErrInvalidScan
+	case b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return Char(0), err
+		}
+		return decodeChar(s)
+	case string(b) == "null":
+		return Char(0), ErrNullValueNotAllowed
+	}
+	code, err := strconv.ParseInt(string(b), 10, 32)
+	if err != nil || code < 0 || code > utf8.MaxRune {
+		return Char(0), ErrInvalidScan
+	}
+	return Char(code), nil
+}
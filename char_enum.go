@@ -0,0 +1,213 @@
+package gosql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// CharEnumItem describes the allowed code of the enum with its symbolic name
+type CharEnumItem struct {
+	Code Char
+	Name string
+}
+
+// CharEnumRegistry keeps the allowed codes of the enum type
+type CharEnumRegistry struct {
+	items     []CharEnumItem
+	byCode    map[Char]string
+	byName    map[string]Char
+	jsonNames bool
+}
+
+// NewCharEnumRegistry creates the registry of the allowed codes,
+// panics if the code or the name is duplicated
+func NewCharEnumRegistry(items ...CharEnumItem) *CharEnumRegistry {
+	reg := &CharEnumRegistry{
+		items:  items,
+		byCode: make(map[Char]string, len(items)),
+		byName: make(map[string]Char, len(items)),
+	}
+	for _, item := range items {
+		if _, ok := reg.byCode[item.Code]; ok {
+			panic(fmt.Sprintf("gosql: duplicate enum code %q", rune(item.Code)))
+		}
+		if _, ok := reg.byName[item.Name]; ok && item.Name != "" {
+			panic(fmt.Sprintf("gosql: duplicate enum name %q", item.Name))
+		}
+		reg.byCode[item.Code] = item.Name
+		if item.Name != "" {
+			reg.byName[item.Name] = item.Code
+		}
+	}
+	return reg
+}
+
+// WithJSONNames enables marshaling of the symbolic names into JSON instead of codes
+func (r *CharEnumRegistry) WithJSONNames(enable bool) *CharEnumRegistry {
+	r.jsonNames = enable
+	return r
+}
+
+// Items returns the list of allowed codes with names
+func (r *CharEnumRegistry) Items() []CharEnumItem {
+	return append([]CharEnumItem(nil), r.items...)
+}
+
+// Codes returns the list of allowed codes
+func (r *CharEnumRegistry) Codes() []Char {
+	codes := make([]Char, 0, len(r.items))
+	for _, item := range r.items {
+		codes = append(codes, item.Code)
+	}
+	return codes
+}
+
+// Has returns true if the code is allowed
+func (r *CharEnumRegistry) Has(code Char) bool {
+	_, ok := r.byCode[code]
+	return ok
+}
+
+// Name returns the symbolic name of the code
+func (r *CharEnumRegistry) Name(code Char) string {
+	return r.byCode[code]
+}
+
+// Code returns the code by the symbolic name
+func (r *CharEnumRegistry) Code(name string) (Char, bool) {
+	code, ok := r.byName[name]
+	return code, ok
+}
+
+// CheckConstraint returns SQL expression for the CHECK constraint of the column
+//
+//	status IN ('A','P','D')
+func (r *CharEnumRegistry) CheckConstraint(column string) string {
+	var buff strings.Builder
+	buff.WriteString(column)
+	buff.WriteString(" IN (")
+	for i, item := range r.items {
+		if i > 0 {
+			buff.WriteByte(',')
+		}
+		buff.WriteByte('\'')
+		buff.WriteString(strings.ReplaceAll(string(item.Code), "'", "''"))
+		buff.WriteByte('\'')
+	}
+	buff.WriteByte(')')
+	return buff.String()
+}
+
+// decode the code from the JSON string which can be the code or the name
+func (r *CharEnumRegistry) decode(s string) (Char, error) {
+	if utf8.RuneCountInString(s) == 1 {
+		if code, _ := decodeChar(s); r.Has(code) {
+			return code, nil
+		}
+	}
+	if code, ok := r.byName[s]; ok {
+		return code, nil
+	}
+	return Char(0), fmt.Errorf("%w: %q", ErrInvalidEnumValue, s)
+}
+
+func (r *CharEnumRegistry) validate(code Char) error {
+	if !r.Has(code) {
+		return fmt.Errorf("%w: %q", ErrInvalidEnumValue, rune(code))
+	}
+	return nil
+}
+
+// CharEnumDefinition is implemented by the type which defines the enum codes
+//
+//	var statusRegistry = gosql.NewCharEnumRegistry(
+//		gosql.CharEnumItem{Code: 'A', Name: "active"},
+//		gosql.CharEnumItem{Code: 'P', Name: "paused"},
+//	).WithJSONNames(true)
+//
+//	type statusDefinition struct{}
+//
+//	func (statusDefinition) CharEnumRegistry() *gosql.CharEnumRegistry { return statusRegistry }
+//
+//	type Status = gosql.CharEnum[statusDefinition]
+//
+//	const StatusActive Status = 'A'
+type CharEnumDefinition interface {
+	CharEnumRegistry() *CharEnumRegistry
+}
+
+// CharEnum is the Char restricted by the codes of the enum definition
+type CharEnum[D CharEnumDefinition] Char
+
+// Registry returns the registry of the enum codes
+func (f CharEnum[D]) Registry() *CharEnumRegistry {
+	var def D
+	return def.CharEnumRegistry()
+}
+
+// Char returns the code as Char
+func (f CharEnum[D]) Char() Char { return Char(f) }
+
+// Name returns the symbolic name of the code
+func (f CharEnum[D]) Name() string { return f.Registry().Name(Char(f)) }
+
+// Valid returns true if the code is allowed
+func (f CharEnum[D]) Valid() bool { return f.Registry().Has(Char(f)) }
+
+// String returns the symbolic name or the code if the name is not defined
+func (f CharEnum[D]) String() string {
+	if name := f.Name(); name != "" {
+		return name
+	}
+	return string(f)
+}
+
+// Value implements the driver.Valuer interface, char field
+func (f CharEnum[D]) Value() (driver.Value, error) {
+	if err := f.Registry().validate(Char(f)); err != nil {
+		return nil, err
+	}
+	return Char(f).Value()
+}
+
+// Scan implements the sql.Scanner interface, char field
+func (f *CharEnum[D]) Scan(value any) error {
+	code, err := decodeChar(value)
+	if err != nil {
+		return err
+	}
+	if err = f.Registry().validate(code); err != nil {
+		return err
+	}
+	*f = CharEnum[D](code)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f CharEnum[D]) MarshalJSON() ([]byte, error) {
+	reg := f.Registry()
+	if err := reg.validate(Char(f)); err != nil {
+		return nil, err
+	}
+	if name := reg.Name(Char(f)); reg.jsonNames && name != "" {
+		return json.Marshal(name)
+	}
+	return Char(f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller, accepts the code or the name
+func (f *CharEnum[D]) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	code, err := f.Registry().decode(s)
+	if err != nil {
+		return err
+	}
+	*f = CharEnum[D](code)
+	return nil
+}
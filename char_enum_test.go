@@ -0,0 +1,87 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testStatusRegistry = NewCharEnumRegistry(
+	CharEnumItem{Code: 'A', Name: "active"},
+	CharEnumItem{Code: 'P', Name: "paused"},
+	CharEnumItem{Code: 'D', Name: "deleted"},
+)
+
+type testStatusDefinition struct{}
+
+func (testStatusDefinition) CharEnumRegistry() *CharEnumRegistry { return testStatusRegistry }
+
+type testStatus = CharEnum[testStatusDefinition]
+
+var testNamedRegistry = NewCharEnumRegistry(
+	CharEnumItem{Code: 'Y', Name: "yes"},
+	CharEnumItem{Code: 'N', Name: "no"},
+	CharEnumItem{Code: '✓', Name: "checked"},
+).WithJSONNames(true)
+
+type testNamedDefinition struct{}
+
+func (testNamedDefinition) CharEnumRegistry() *CharEnumRegistry { return testNamedRegistry }
+
+func TestCharEnum(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		var s testStatus
+		if assert.NoError(t, s.Scan("P")) {
+			assert.Equal(t, testStatus('P'), s)
+			assert.Equal(t, "paused", s.Name())
+			assert.Equal(t, "paused", s.String())
+			assert.True(t, s.Valid())
+		}
+		assert.ErrorIs(t, s.Scan("X"), ErrInvalidEnumValue)
+		assert.ErrorIs(t, s.Scan(nil), ErrNullValueNotAllowed)
+		assert.Equal(t, testStatus('P'), s)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := testStatus('A').Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "A", v)
+		_, err = testStatus(0).Value()
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(testStatus('D'))
+		assert.NoError(t, err)
+		assert.Equal(t, `"D"`, string(data))
+
+		data, err = json.Marshal(CharEnum[testNamedDefinition]('✓'))
+		assert.NoError(t, err)
+		assert.Equal(t, `"checked"`, string(data))
+
+		var s testStatus
+		assert.NoError(t, json.Unmarshal([]byte(`"A"`), &s))
+		assert.Equal(t, testStatus('A'), s)
+		assert.NoError(t, json.Unmarshal([]byte(`"deleted"`), &s))
+		assert.Equal(t, testStatus('D'), s)
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"X"`), &s), ErrInvalidEnumValue)
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"unknown"`), &s), ErrInvalidEnumValue)
+
+		var n CharEnum[testNamedDefinition]
+		assert.NoError(t, json.Unmarshal([]byte(`"✓"`), &n))
+		assert.Equal(t, CharEnum[testNamedDefinition]('✓'), n)
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		assert.Equal(t, []Char{'A', 'P', 'D'}, testStatusRegistry.Codes())
+		assert.Equal(t, "status IN ('A','P','D')", testStatusRegistry.CheckConstraint("status"))
+		code, ok := testStatusRegistry.Code("active")
+		assert.True(t, ok)
+		assert.Equal(t, Char('A'), code)
+		assert.Len(t, testStatusRegistry.Items(), 3)
+		assert.Panics(t, func() {
+			NewCharEnumRegistry(CharEnumItem{Code: 'A'}, CharEnumItem{Code: 'A'})
+		})
+	})
+}
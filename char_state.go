@@ -0,0 +1,248 @@
+package gosql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// TransitionError describes the not allowed transition between states
+type TransitionError struct {
+	From     Char
+	To       Char
+	FromName string
+	ToName   string
+}
+
+// Error implements the error interface
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s from %s to %s", ErrInvalidTransition.Error(),
+		charStateLabel(e.From, e.FromName), charStateLabel(e.To, e.ToName))
+}
+
+// Unwrap returns ErrInvalidTransition
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// CharStateMachine defines the allowed states and transitions between them
+type CharStateMachine struct {
+	*CharEnumRegistry
+	transitions map[Char]map[Char]struct{}
+}
+
+// NewCharStateMachine creates the state machine over the registry of states
+func NewCharStateMachine(registry *CharEnumRegistry) *CharStateMachine {
+	return &CharStateMachine{
+		CharEnumRegistry: registry,
+		transitions:      map[Char]map[Char]struct{}{},
+	}
+}
+
+// Allow transitions from the state to the target states,
+// panics if any of states is not registered
+func (m *CharStateMachine) Allow(from Char, to ...Char) *CharStateMachine {
+	for _, code := range append([]Char{from}, to...) {
+		if !m.Has(code) {
+			panic(fmt.Sprintf("gosql: unknown state %q", rune(code)))
+		}
+	}
+	if m.transitions[from] == nil {
+		m.transitions[from] = map[Char]struct{}{}
+	}
+	for _, code := range to {
+		m.transitions[from][code] = struct{}{}
+	}
+	return m
+}
+
+// CanTransition returns true if the transition is allowed,
+// staying in the same state is always allowed
+func (m *CharStateMachine) CanTransition(from, to Char) bool {
+	if !m.Has(from) || !m.Has(to) {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	_, ok := m.transitions[from][to]
+	return ok
+}
+
+// Transitions returns the list of states available from the state
+func (m *CharStateMachine) Transitions(from Char) []Char {
+	codes := make([]Char, 0, len(m.transitions[from]))
+	for _, code := range m.Codes() {
+		if _, ok := m.transitions[from][code]; ok {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// Validate returns the error if the transition is not allowed
+func (m *CharStateMachine) Validate(from, to Char) error {
+	if err := m.validate(to); err != nil {
+		return err
+	}
+	if !m.CanTransition(from, to) {
+		return &TransitionError{From: from, To: to, FromName: m.Name(from), ToName: m.Name(to)}
+	}
+	return nil
+}
+
+// CharStateDefinition is implemented by the type which defines the state machine
+//
+//	var orderStates = gosql.NewCharStateMachine(gosql.NewCharEnumRegistry(
+//		gosql.CharEnumItem{Code: 'D', Name: "draft"},
+//		gosql.CharEnumItem{Code: 'A', Name: "active"},
+//		gosql.CharEnumItem{Code: 'P', Name: "paused"},
+//	)).Allow('D', 'A').Allow('A', 'P').Allow('P', 'A')
+//
+//	type orderStateDefinition struct{}
+//
+//	func (orderStateDefinition) CharStateMachine() *gosql.CharStateMachine { return orderStates }
+//
+//	type OrderState = gosql.CharState[orderStateDefinition]
+type CharStateDefinition interface {
+	CharStateMachine() *CharStateMachine
+}
+
+// CharState is the Char status restricted by the state machine transitions
+//
+// The state remembers the origin value loaded from the database,
+// so the transition from the origin to the current state can be validated
+// before saving. The zero origin means the new object and any valid state is allowed.
+// The chain of allowed Transition calls between saves is valid as well.
+type CharState[D CharStateDefinition] struct {
+	state   Char
+	origin  Char
+	reached Char // the state reached from the origin by allowed transitions
+}
+
+// NewCharState creates the state with initial code
+func NewCharState[D CharStateDefinition](code Char) CharState[D] {
+	return CharState[D]{state: code}
+}
+
+// Machine returns the state machine of the definition
+func (s CharState[D]) Machine() *CharStateMachine {
+	var def D
+	return def.CharStateMachine()
+}
+
+// Code of the current state
+func (s CharState[D]) Code() Char { return s.state }
+
+// Origin returns the code of the state loaded from the database
+func (s CharState[D]) Origin() Char { return s.origin }
+
+// Name returns the symbolic name of the current state
+func (s CharState[D]) Name() string { return s.Machine().Name(s.state) }
+
+// String returns the symbolic name or the code if the name is not defined
+func (s CharState[D]) String() string {
+	return charStateLabel(s.state, s.Name())
+}
+
+// Changed returns true if the current state differs from the origin
+func (s CharState[D]) Changed() bool { return s.state != s.origin }
+
+// CanTransition returns true if the transition from the current state is allowed
+func (s CharState[D]) CanTransition(to Char) bool {
+	if s.state == 0 {
+		return s.Machine().Has(to)
+	}
+	return s.Machine().CanTransition(s.state, to)
+}
+
+// Transition changes the state if the transition is allowed
+// or returns *TransitionError otherwise
+func (s *CharState[D]) Transition(to Char) error {
+	var err error
+	if s.state == 0 {
+		err = s.Machine().validate(to)
+	} else {
+		err = s.Machine().Validate(s.state, to)
+	}
+	if err == nil {
+		if s.state == s.origin || s.state == s.reached {
+			s.reached = to
+		}
+		s.state = to
+	}
+	return err
+}
+
+// Validate the transition from the origin to the current state,
+// the state reached by the chain of allowed transitions is valid
+func (s CharState[D]) Validate() error {
+	if s.origin == 0 {
+		return s.Machine().validate(s.state)
+	}
+	if s.reached != 0 && s.state == s.reached {
+		return nil
+	}
+	return s.Machine().Validate(s.origin, s.state)
+}
+
+// Commit marks the current state as stored in the database
+func (s *CharState[D]) Commit() {
+	s.origin, s.reached = s.state, 0
+}
+
+// Value implements the driver.Valuer interface, char field
+func (s CharState[D]) Value() (driver.Value, error) {
+	if err := s.Machine().validate(s.state); err != nil {
+		return nil, err
+	}
+	return s.state.Value()
+}
+
+// Scan implements the sql.Scanner interface, char field
+func (s *CharState[D]) Scan(value any) error {
+	code, err := decodeChar(value)
+	if err != nil {
+		return err
+	}
+	if err = s.Machine().validate(code); err != nil {
+		return err
+	}
+	s.state, s.origin, s.reached = code, code, 0
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler
+func (s CharState[D]) MarshalJSON() ([]byte, error) {
+	m := s.Machine()
+	if err := m.validate(s.state); err != nil {
+		return nil, err
+	}
+	if name := m.Name(s.state); m.jsonNames && name != "" {
+		return json.Marshal(name)
+	}
+	return s.state.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller, accepts the code or the name
+//
+// The origin is not changed so the transition can be validated later.
+func (s *CharState[D]) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	code, err := s.Machine().decode(str)
+	if err != nil {
+		return err
+	}
+	s.state = code
+	return nil
+}
+
+func charStateLabel(code Char, name string) string {
+	if name != "" {
+		return fmt.Sprintf("%q (%c)", name, rune(code))
+	}
+	return fmt.Sprintf("%q", rune(code))
+}
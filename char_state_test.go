@@ -0,0 +1,102 @@
+package gosql
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOrderStates = NewCharStateMachine(NewCharEnumRegistry(
+	CharEnumItem{Code: 'D', Name: "draft"},
+	CharEnumItem{Code: 'A', Name: "active"},
+	CharEnumItem{Code: 'P', Name: "paused"},
+	CharEnumItem{Code: 'R', Name: "archived"},
+)).
+	Allow('D', 'A').
+	Allow('A', 'P', 'R').
+	Allow('P', 'A')
+
+type testOrderStateDefinition struct{}
+
+func (testOrderStateDefinition) CharStateMachine() *CharStateMachine { return testOrderStates }
+
+type testOrderState = CharState[testOrderStateDefinition]
+
+func TestCharState(t *testing.T) {
+	t.Run("transition", func(t *testing.T) {
+		s := NewCharState[testOrderStateDefinition]('D')
+		assert.True(t, s.CanTransition('A'))
+		assert.False(t, s.CanTransition('P'))
+		assert.NoError(t, s.Transition('A'))
+		assert.NoError(t, s.Transition('P'))
+		assert.NoError(t, s.Transition('A'))
+		assert.NoError(t, s.Transition('R'))
+		assert.Equal(t, "archived", s.Name())
+
+		err := s.Transition('D')
+		var terr *TransitionError
+		if assert.True(t, errors.As(err, &terr)) {
+			assert.Equal(t, Char('R'), terr.From)
+			assert.Equal(t, Char('D'), terr.To)
+			assert.Equal(t, `invalid state transition from "archived" (R) to "draft" (D)`, err.Error())
+		}
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+		assert.ErrorIs(t, s.Transition('X'), ErrInvalidEnumValue)
+		assert.Equal(t, []Char{'P', 'R'}, testOrderStates.Transitions('A'))
+	})
+
+	t.Run("origin", func(t *testing.T) {
+		var s testOrderState
+		assert.NoError(t, s.Scan("A"))
+		assert.Equal(t, Char('A'), s.Origin())
+		assert.False(t, s.Changed())
+		assert.NoError(t, s.Validate())
+
+		assert.NoError(t, json.Unmarshal([]byte(`"draft"`), &s))
+		assert.True(t, s.Changed())
+		assert.ErrorIs(t, s.Validate(), ErrInvalidTransition)
+
+		assert.NoError(t, json.Unmarshal([]byte(`"P"`), &s))
+		assert.NoError(t, s.Validate())
+		s.Commit()
+		assert.False(t, s.Changed())
+	})
+
+	t.Run("origin:chain", func(t *testing.T) {
+		var s testOrderState
+		assert.NoError(t, s.Scan("D"))
+		assert.NoError(t, s.Transition('A'))
+		assert.NoError(t, s.Transition('P'))
+		assert.NoError(t, s.Validate(), "D -> A -> P is allowed")
+
+		// the state set without Transition is validated from the origin
+		assert.NoError(t, json.Unmarshal([]byte(`"R"`), &s))
+		assert.ErrorIs(t, s.Validate(), ErrInvalidTransition)
+		assert.NoError(t, json.Unmarshal([]byte(`"P"`), &s))
+		assert.NoError(t, s.Validate())
+
+		s.Commit()
+		assert.NoError(t, json.Unmarshal([]byte(`"R"`), &s))
+		assert.ErrorIs(t, s.Validate(), ErrInvalidTransition, "P -> R is not allowed")
+		assert.NoError(t, s.Scan("D"))
+		assert.NoError(t, json.Unmarshal([]byte(`"P"`), &s))
+		assert.ErrorIs(t, s.Validate(), ErrInvalidTransition, "the chain is reset by Scan")
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		var s testOrderState
+		assert.ErrorIs(t, s.Scan("X"), ErrInvalidEnumValue)
+		_, err := s.Value()
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		assert.NoError(t, s.Transition('D'))
+		v, err := s.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "D", v)
+
+		data, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, `"D"`, string(data))
+	})
+}
@@ -1,6 +1,7 @@
 package gosql
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,8 @@ func TestChar(t *testing.T) {
 		{"string_multi_char", "ABC", Char('A'), false},
 		{"byte_slice", []byte("B"), Char('B'), false},
 		{"byte_slice_multi", []byte("BCD"), Char('B'), false},
+		{"string_unicode", "Ёж", Char('Ё'), false},
+		{"byte_slice_unicode", []byte("✓"), Char('✓'), false},
 		{"rune", rune('C'), Char('C'), false},
 		{"int", int(65), Char('A'), false},
 		{"uint16", uint16(66), Char('B'), false},
@@ -100,15 +103,16 @@ func TestCharJSON(t *testing.T) {
 
 	t.Run("unmarshal:direct", func(t *testing.T) {
 		// Test direct UnmarshalJSON calls
-		// Note: The current implementation uses decodeChar directly on the JSON bytes,
-		// which includes quotes, so it will get the quote character
 		tests := []struct {
 			json     string
 			expected Char
 		}{
-			{`"A"`, Char('"')}, // Gets the first character (quote)
-			{`"5"`, Char('"')}, // Gets the first character (quote)
-			{`" "`, Char('"')}, // Gets the first character (quote)
+			{`"A"`, Char('A')},
+			{`"5"`, Char('5')},
+			{`" "`, Char(' ')},
+			{`"Ё"`, Char('Ё')},
+			{`"\u2713"`, Char('✓')},
+			{`65`, Char('A')},
 		}
 
 		for _, test := range tests {
@@ -131,6 +135,9 @@ func TestCharJSON(t *testing.T) {
 		err = c.UnmarshalJSON([]byte{})
 		assert.Error(t, err)
 		assert.Equal(t, ErrInvalidScan, err)
+
+		assert.Equal(t, ErrInvalidScan, c.UnmarshalJSON([]byte(`""`)))
+		assert.Equal(t, ErrNullValueNotAllowed, c.UnmarshalJSON([]byte(`null`)))
 	})
 }
 
@@ -151,18 +158,32 @@ func TestCharEdgeCases(t *testing.T) {
 	})
 
 	t.Run("high_unicode_values", func(t *testing.T) {
-		// Test with high Unicode values - note: MarshalJSON only handles byte range
-		c := Char(0x1F600) // 😀 emoji (but MarshalJSON casts to byte)
+		c := Char(0x1F600) // 😀 emoji
 		value, err := c.Value()
 		assert.NoError(t, err)
 		assert.Equal(t, "😀", value)
 
-		// MarshalJSON casts to byte, so high Unicode values get truncated
 		data, err := c.MarshalJSON()
 		assert.NoError(t, err)
-		// 0x1F600 as byte is 0x00, but since c != 0, it doesn't return space
-		// It returns []byte{'"', 0, '"'} which is a null character between quotes
-		assert.Equal(t, "\"\x00\"", string(data))
+		assert.Equal(t, `"😀"`, string(data))
+
+		var c2 Char
+		assert.NoError(t, c2.UnmarshalJSON(data))
+		assert.Equal(t, c, c2)
+	})
+
+	t.Run("escaped_characters", func(t *testing.T) {
+		for _, c := range []Char{'"', '\\', '\n', 'Ё', '✓'} {
+			data, err := c.MarshalJSON()
+			assert.NoError(t, err)
+			var s string
+			assert.NoError(t, json.Unmarshal(data, &s))
+			assert.Equal(t, string(c), s)
+
+			var c2 Char
+			assert.NoError(t, c2.UnmarshalJSON(data))
+			assert.Equal(t, c, c2)
+		}
 	})
 
 	t.Run("byte_range_characters", func(t *testing.T) {
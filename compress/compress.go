@@ -0,0 +1,119 @@
+// Package compress registers the zstd and snappy compressors of gosql.CompressedJSON.
+//
+//	func init() {
+//		compress.Register()
+//	}
+package compress
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// Register the zstd and snappy compressors
+func Register() {
+	gosql.RegisterJSONCompressor(gosql.JSONCompressionZstd, NewZstd())
+	gosql.RegisterJSONCompressor(gosql.JSONCompressionSnappy, Snappy{})
+}
+
+// Zstd compressor, encoders of levels and streaming decoders are shared
+type Zstd struct {
+	encoders sync.Map  // int -> *zstd.Encoder
+	decoders sync.Pool // *zstd.Decoder
+}
+
+// NewZstd creates new zstd compressor
+func NewZstd() *Zstd {
+	return &Zstd{}
+}
+
+// Compress implements gosql.JSONCompressor, the level is the zstd level 1-22
+func (c *Zstd) Compress(data []byte, level int) ([]byte, error) {
+	enc, err := c.encoder(level)
+	if err != nil {
+		return nil, err
+	}
+	return enc.EncodeAll(data, nil), nil
+}
+
+// Decompress implements gosql.JSONCompressor, the data is decoded as the stream
+// so the decoding stops as soon as the limit is exceeded
+func (c *Zstd) Decompress(data []byte, limit int) ([]byte, error) {
+	dec, _ := c.decoders.Get().(*zstd.Decoder)
+	if dec == nil {
+		var err error
+		// the synchronous decoder has no goroutines to close
+		if dec, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		_ = dec.Reset(nil)
+		c.decoders.Put(dec)
+	}()
+	// bytes.Reader is not decoded in memory at once like bytes.Buffer
+	if err := dec.Reset(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	var src io.Reader = dec
+	if limit > 0 {
+		src = io.LimitReader(dec, int64(limit)+1)
+	}
+	res, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(res) > limit {
+		return nil, gosql.ErrJSONTooLarge
+	}
+	return res, nil
+}
+
+func (c *Zstd) encoder(level int) (*zstd.Encoder, error) {
+	if enc, ok := c.encoders.Load(level); ok {
+		return enc.(*zstd.Encoder), nil
+	}
+	encLevel := zstd.SpeedDefault
+	if level != 0 {
+		encLevel = zstd.EncoderLevelFromZstd(level)
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encLevel), zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := c.encoders.LoadOrStore(level, enc)
+	return actual.(*zstd.Encoder), nil
+}
+
+// Snappy compressor of the snappy block format,
+// the level 2 is the better and 3 is the best compression
+type Snappy struct{}
+
+// Compress implements gosql.JSONCompressor
+func (Snappy) Compress(data []byte, level int) ([]byte, error) {
+	switch {
+	case level >= 3:
+		return s2.EncodeSnappyBest(nil, data), nil
+	case level == 2:
+		return s2.EncodeSnappyBetter(nil, data), nil
+	}
+	return s2.EncodeSnappy(nil, data), nil
+}
+
+// Decompress implements gosql.JSONCompressor
+func (Snappy) Decompress(data []byte, limit int) ([]byte, error) {
+	size, err := s2.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && size > limit {
+		return nil, gosql.ErrJSONTooLarge
+	}
+	return s2.Decode(nil, data)
+}
@@ -0,0 +1,94 @@
+package compress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+type testReport struct {
+	Name  string   `json:"name"`
+	Lines []string `json:"lines"`
+}
+
+func TestCompressedJSON(t *testing.T) {
+	Register()
+	defer gosql.RegisterJSONCompressor(gosql.JSONCompressionZstd, nil)
+	defer gosql.RegisterJSONCompressor(gosql.JSONCompressionSnappy, nil)
+
+	report := testReport{Name: "report"}
+	for i := 0; i < 100; i++ {
+		report.Lines = append(report.Lines, "the same line of the report")
+	}
+
+	for _, algorithm := range []gosql.JSONCompression{gosql.JSONCompressionZstd, gosql.JSONCompressionSnappy} {
+		for _, level := range []int{0, 1, 3} {
+			gosql.RegisterJSONCompressionOptions[testReport](&gosql.JSONCompressionOptions{Algorithm: algorithm, Level: level})
+
+			var js gosql.CompressedJSON[testReport]
+			js.Data = report
+			v, err := js.Value()
+			assert.NoError(t, err, algorithm.String())
+			data, _ := v.([]byte)
+			if assert.True(t, len(data) > 4, algorithm.String()) {
+				assert.Equal(t, []byte{0xC0, 'J', 1, byte(algorithm)}, data[:4])
+			}
+
+			var res gosql.CompressedJSON[testReport]
+			assert.NoError(t, res.Scan(data), algorithm.String())
+			assert.Equal(t, report, res.Data, algorithm.String())
+		}
+	}
+	gosql.RegisterJSONCompressionOptions[testReport](nil)
+}
+
+func TestDecompressLimit(t *testing.T) {
+	data := []byte(`"` + strings.Repeat("a", 1000) + `"`)
+	for _, c := range []gosql.JSONCompressor{NewZstd(), Snappy{}} {
+		compressed, err := c.Compress(data, 0)
+		assert.NoError(t, err)
+		_, err = c.Decompress(compressed, 100)
+		assert.ErrorIs(t, err, gosql.ErrJSONTooLarge)
+		res, err := c.Decompress(compressed, len(data))
+		assert.NoError(t, err)
+		assert.Equal(t, data, res)
+		_, err = c.Decompress([]byte("invalid"), 0)
+		assert.Error(t, err)
+	}
+}
+
+func TestZstdDecompressLimitWithoutContentSize(t *testing.T) {
+	// the streaming encoder does not write the content size into the frame header
+	var buf bytes.Buffer
+	enc, err := zstd.NewWriter(&buf)
+	assert.NoError(t, err)
+	_, err = enc.Write(bytes.Repeat([]byte("a"), 4<<20))
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Close())
+
+	var header zstd.Header
+	assert.NoError(t, header.Decode(buf.Bytes()))
+	assert.False(t, header.HasFCS)
+
+	c := NewZstd()
+	_, err = c.Decompress(buf.Bytes(), 1000)
+	assert.ErrorIs(t, err, gosql.ErrJSONTooLarge)
+	res, err := c.Decompress(buf.Bytes(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, res, 4<<20)
+}
+
+func TestSnappyCompatibility(t *testing.T) {
+	data := []byte(strings.Repeat(`{"a":1}`, 100))
+	compressed, err := Snappy{}.Compress(data, 3)
+	assert.NoError(t, err)
+	res, err := snappy.Decode(nil, compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, data, res)
+}
@@ -0,0 +1,517 @@
+package gosql
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CopyFormat is the format of the PostgreSQL `COPY ... FROM STDIN` and `COPY ... TO STDOUT` data
+type CopyFormat uint8
+
+// Supported COPY formats
+const (
+	// CopyText is the text format with `\N` NULL and backslash escapes
+	CopyText CopyFormat = iota
+
+	// CopyCSV is the CSV format with the unquoted empty NULL
+	CopyCSV
+)
+
+const copyTimeLayout = "2006-01-02 15:04:05.999999999Z07:00"
+
+// CopyOptions of the COPY data, zero values are replaced by the defaults of the format
+type CopyOptions struct {
+	Format CopyFormat
+
+	// Delimiter of the fields, tab for the text and comma for CSV
+	Delimiter byte
+
+	// Null is the NULL token, `\N` for the text and the empty string for CSV
+	Null string
+
+	// Quote of CSV fields, double quote by default
+	Quote byte
+
+	// DurationFormatter of Duration and NullableDuration fields, PostgresIntervalFormat by default
+	DurationFormatter DurationFormatter
+}
+
+func (o CopyOptions) withDefaults() CopyOptions {
+	if o.Delimiter == 0 {
+		if o.Format == CopyCSV {
+			o.Delimiter = ','
+		} else {
+			o.Delimiter = '\t'
+		}
+	}
+	if o.Null == "" && o.Format != CopyCSV {
+		o.Null = `\N`
+	}
+	if o.Quote == 0 {
+		o.Quote = '"'
+	}
+	if o.DurationFormatter == nil {
+		o.DurationFormatter = PostgresIntervalFormat
+	}
+	return o
+}
+
+// CopyEncoder writes rows in the COPY format.
+// Values are encoded by driver.Valuer, so every gosql type is written
+// in the same form as by the database driver, except durations which are written as intervals.
+//
+//	enc := gosql.NewCopyEncoder(w, gosql.CopyOptions{})
+//	err := enc.Encode(id, gosql.NumberArray[int64]{1, 2}, gosql.JSON[Settings]{Data: settings})
+type CopyEncoder struct {
+	w    io.Writer
+	opts CopyOptions
+	buf  []byte
+}
+
+// NewCopyEncoder returns the encoder of rows into the writer
+func NewCopyEncoder(w io.Writer, opts CopyOptions) *CopyEncoder {
+	return &CopyEncoder{w: w, opts: opts.withDefaults()}
+}
+
+// Encode writes the row of values terminated by the newline
+func (e *CopyEncoder) Encode(values ...any) (err error) {
+	if e.buf, err = AppendCopyRow(e.buf[:0], e.opts, values...); err != nil {
+		return err
+	}
+	_, err = e.w.Write(e.buf)
+	return err
+}
+
+// AppendCopyRow appends the row of values terminated by the newline to the buffer
+func AppendCopyRow(buf []byte, opts CopyOptions, values ...any) ([]byte, error) {
+	opts = opts.withDefaults()
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, opts.Delimiter)
+		}
+		s, null, err := copyFieldText(v, opts)
+		if err != nil {
+			return buf, fmt.Errorf("%w: field %d: %w", ErrInvalidCopyData, i, err)
+		}
+		switch {
+		case null:
+			buf = append(buf, opts.Null...)
+		case opts.Format == CopyCSV:
+			buf = appendCopyCSV(buf, s, opts)
+		default:
+			buf = appendCopyText(buf, s, opts.Delimiter)
+		}
+	}
+	return append(buf, '\n'), nil
+}
+
+// CopyDecoder reads rows in the COPY format
+//
+//	dec := gosql.NewCopyDecoder(r, gosql.CopyOptions{Format: gosql.CopyCSV})
+//	for {
+//		if err := dec.Decode(&id, &tags, &settings); err == io.EOF {
+//			break
+//		}
+//	}
+type CopyDecoder struct {
+	r    *bufio.Reader
+	opts CopyOptions
+	line int
+}
+
+// NewCopyDecoder returns the decoder of rows from the reader
+func NewCopyDecoder(r io.Reader, opts CopyOptions) *CopyDecoder {
+	return &CopyDecoder{r: bufio.NewReader(r), opts: opts.withDefaults()}
+}
+
+// ReadRow returns the next row, fields are strings or nil for NULL.
+// Returns io.EOF at the end of data or at the `\.` end marker.
+func (d *CopyDecoder) ReadRow() ([]any, error) {
+	d.line++
+	if d.opts.Format == CopyCSV {
+		return d.readCSVRow()
+	}
+	return d.readTextRow()
+}
+
+// Decode reads the next row into the destinations.
+// Destinations can be sql.Scanner or pointers to strings, bytes, numbers, bools, times and pointers to them.
+func (d *CopyDecoder) Decode(dest ...any) error {
+	row, err := d.ReadRow()
+	if err != nil {
+		return err
+	}
+	if len(row) != len(dest) {
+		return d.errorf("expected %d fields, got %d", len(dest), len(row))
+	}
+	for i, value := range row {
+		if err := scanCopyField(dest[i], value); err != nil {
+			return fmt.Errorf("%w: line %d: field %d: %w", ErrInvalidCopyData, d.line, i, err)
+		}
+	}
+	return nil
+}
+
+func (d *CopyDecoder) readTextRow() ([]any, error) {
+	line, err := d.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return nil, err
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	if line == `\.` {
+		return nil, io.EOF
+	}
+	var (
+		row   []any
+		field strings.Builder
+		start = 0
+	)
+	for i := 0; i <= len(line); i++ {
+		if i == len(line) || line[i] == d.opts.Delimiter {
+			if line[start:i] == d.opts.Null {
+				row = append(row, nil)
+			} else {
+				row = append(row, field.String())
+			}
+			field.Reset()
+			start = i + 1
+			continue
+		}
+		if line[i] != '\\' {
+			field.WriteByte(line[i])
+			continue
+		}
+		if i++; i == len(line) {
+			return nil, d.errorf("unexpected end of line after escape")
+		}
+		switch c := line[i]; c {
+		case 'b':
+			field.WriteByte('\b')
+		case 'f':
+			field.WriteByte('\f')
+		case 'n':
+			field.WriteByte('\n')
+		case 'r':
+			field.WriteByte('\r')
+		case 't':
+			field.WriteByte('\t')
+		case 'v':
+			field.WriteByte('\v')
+		case 'x':
+			n, size := parseCopyDigits(line[i+1:], 16, 2)
+			if size == 0 {
+				field.WriteByte(c)
+				break
+			}
+			field.WriteByte(byte(n))
+			i += size
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			n, size := parseCopyDigits(line[i:], 8, 3)
+			field.WriteByte(byte(n))
+			i += size - 1
+		default:
+			field.WriteByte(c)
+		}
+	}
+	return row, nil
+}
+
+func (d *CopyDecoder) readCSVRow() ([]any, error) {
+	var (
+		row    []any
+		field  strings.Builder
+		quoted bool // the field has quoted part
+	)
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF && len(row) == 0 && field.Len() == 0 && !quoted {
+			return nil, io.EOF
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		switch {
+		case err == io.EOF || c == '\n' || c == d.opts.Delimiter:
+			value := field.String()
+			if c == '\n' && !quoted {
+				value = strings.TrimSuffix(value, "\r")
+			}
+			switch {
+			case len(row) == 0 && !quoted && value == `\.` && c != d.opts.Delimiter:
+				return nil, io.EOF
+			case !quoted && value == d.opts.Null:
+				row = append(row, nil)
+			default:
+				row = append(row, value)
+			}
+			if err == io.EOF || c == '\n' {
+				return row, nil
+			}
+			field.Reset()
+			quoted = false
+		case c == d.opts.Quote:
+			quoted = true
+			if err := d.readCSVQuoted(&field); err != nil {
+				return nil, err
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+}
+
+// readCSVQuoted reads the quoted part of the field after the opening quote
+func (d *CopyDecoder) readCSVQuoted(field *strings.Builder) error {
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			return d.errorf("unterminated quoted field")
+		}
+		if err != nil {
+			return err
+		}
+		if c == '\n' {
+			d.line++
+		}
+		if c != d.opts.Quote {
+			field.WriteByte(c)
+			continue
+		}
+		if next, err := d.r.Peek(1); err == nil && next[0] == d.opts.Quote {
+			_, _ = d.r.ReadByte()
+			field.WriteByte(c)
+			continue
+		}
+		return nil
+	}
+}
+
+func (d *CopyDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidCopyData, d.line, fmt.Sprintf(format, args...))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// copyFieldText returns the text of the value or null flag
+func copyFieldText(v any, opts CopyOptions) (string, bool, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", true, nil
+	case Duration:
+		return opts.DurationFormatter.Format(val), false, nil
+	case NullableDuration:
+		if !val.Valid {
+			return "", true, nil
+		}
+		return opts.DurationFormatter.Format(val.Duration), false, nil
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", true, nil
+		}
+		dv, err := val.Value()
+		if err != nil {
+			return "", false, err
+		}
+		return copyFieldText(dv, opts)
+	case string:
+		return val, false, nil
+	case []byte:
+		if val == nil {
+			return "", true, nil
+		}
+		return `\x` + hex.EncodeToString(val), false, nil
+	case bool:
+		if val {
+			return "t", false, nil
+		}
+		return "f", false, nil
+	case int64:
+		return strconv.FormatInt(val, 10), false, nil
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), false, nil
+	case time.Time:
+		return val.Format(copyTimeLayout), false, nil
+	case fmt.Stringer:
+		return val.String(), false, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "", true, nil
+		}
+		return copyFieldText(rv.Elem().Interface(), opts)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), false, nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), false, nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), false, nil
+	case reflect.Bool:
+		return copyFieldText(rv.Bool(), opts)
+	case reflect.String:
+		return rv.String(), false, nil
+	}
+	return "", false, fmt.Errorf("unsupported type %T", v)
+}
+
+// appendCopyText writes the field escaping backslashes, control characters and the delimiter
+func appendCopyText(buf []byte, s string, delim byte) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			buf = append(buf, `\\`...)
+		case '\b':
+			buf = append(buf, `\b`...)
+		case '\f':
+			buf = append(buf, `\f`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		case '\v':
+			buf = append(buf, `\v`...)
+		case delim:
+			buf = append(buf, '\\', c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// appendCopyCSV writes the field quoting it if it contains special characters
+// or can be confused with NULL or the end marker
+func appendCopyCSV(buf []byte, s string, opts CopyOptions) []byte {
+	if s != opts.Null && s != `\.` && strings.IndexFunc(s, func(r rune) bool {
+		return r == rune(opts.Delimiter) || r == rune(opts.Quote) || r == '\n' || r == '\r'
+	}) < 0 {
+		return append(buf, s...)
+	}
+	buf = append(buf, opts.Quote)
+	for i := 0; i < len(s); i++ {
+		if s[i] == opts.Quote {
+			buf = append(buf, opts.Quote)
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, opts.Quote)
+}
+
+// parseCopyDigits parses up to max digits of the base at the beginning of s
+func parseCopyDigits(s string, base, max int) (n, size int) {
+	for size < max && size < len(s) {
+		d, err := strconv.ParseUint(s[size:size+1], base, 8)
+		if err != nil {
+			break
+		}
+		n = n*base + int(d)
+		size++
+	}
+	return n, size
+}
+
+// scanCopyField sets the destination from the field value, the string or nil for NULL
+func scanCopyField(dest, value any) error {
+	switch d := dest.(type) {
+	case sql.Scanner:
+		return d.Scan(value)
+	case *any:
+		*d = value
+		return nil
+	case *[]byte:
+		if value == nil {
+			*d = nil
+			return nil
+		}
+		s := value.(string)
+		if strings.HasPrefix(s, `\x`) {
+			b, err := hex.DecodeString(s[2:])
+			if err != nil {
+				return err
+			}
+			*d = b
+			return nil
+		}
+		*d = []byte(s)
+		return nil
+	case *time.Time:
+		if value == nil {
+			return ErrNullValueNotAllowed
+		}
+		t, err := time.Parse(copyTimeLayout, value.(string))
+		if err != nil {
+			t, err = time.Parse(time.RFC3339Nano, value.(string))
+		}
+		if err == nil {
+			*d = t
+		}
+		return err
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrInvalidScanValue
+	}
+	elem := rv.Elem()
+	if elem.Kind() == reflect.Pointer {
+		if value == nil {
+			elem.SetZero()
+			return nil
+		}
+		target := reflect.New(elem.Type().Elem())
+		if err := scanCopyField(target.Interface(), value); err != nil {
+			return err
+		}
+		elem.Set(target)
+		return nil
+	}
+	if value == nil {
+		return ErrNullValueNotAllowed
+	}
+	s := value.(string)
+	switch elem.Kind() {
+	case reflect.String:
+		elem.SetString(s)
+	case reflect.Bool:
+		v, err := BoolCodec{}.DecodeElement(s)
+		if err != nil {
+			return err
+		}
+		elem.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, elem.Type().Bits())
+		if err != nil {
+			return err
+		}
+		elem.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(s, 10, elem.Type().Bits())
+		if err != nil {
+			return err
+		}
+		elem.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, elem.Type().Bits())
+		if err != nil {
+			return err
+		}
+		elem.SetFloat(v)
+	default:
+		return ErrInvalidScanValue
+	}
+	return nil
+}
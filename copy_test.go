@@ -0,0 +1,150 @@
+package gosql
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyEncoder(t *testing.T) {
+	type doc struct {
+		Note string `json:"note"`
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC)
+	row := []any{
+		int64(1),
+		"tab\there\\ and\nline",
+		NumberArray[int64]{1, 2},
+		StringArray{"a", "b c"},
+		JSON[doc]{Data: doc{Note: "x\ny"}},
+		Char('y'),
+		Duration(26 * Hour),
+		NullableDuration{},
+		Interval{Months: 1},
+		nil,
+		[]byte{1, 0xab},
+		true,
+		ts,
+		(*Duration)(nil),
+	}
+	tests := []struct {
+		name   string
+		opts   CopyOptions
+		expect string
+	}{
+		{
+			name: "text",
+			expect: "1\ttab\\there\\\\ and\\nline\t{1,2}\t{a,\"b c\"}\t{\"note\":\"x\\\\ny\"}\ty\t" +
+				"1 day 02:00:00\t\\N\t1 mon\t\\N\t\\\\x01ab\tt\t2024-01-02 03:04:05.6Z\t\\N\n",
+		},
+		{
+			name: "csv",
+			opts: CopyOptions{Format: CopyCSV},
+			expect: "1,\"tab\there\\ and\nline\",\"{1,2}\",\"{a,\"\"b c\"\"}\",\"{\"\"note\"\":\"\"x\\ny\"\"}\",y," +
+				"1 day 02:00:00,,1 mon,,\\x01ab,t,2024-01-02 03:04:05.6Z,\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewCopyEncoder(&buf, test.opts)
+			assert.NoError(t, enc.Encode(row...))
+			assert.Equal(t, test.expect, buf.String())
+
+			var (
+				id       int64
+				text     string
+				nums     NumberArray[int64]
+				strs     StringArray
+				js       JSON[doc]
+				char     Char
+				dur      Duration
+				nullDur  = NewNullableDuration(Second)
+				interval Interval
+				null     *string
+				raw      []byte
+				flag     bool
+				tm       time.Time
+				ptr      = new(int)
+			)
+			dec := NewCopyDecoder(&buf, test.opts)
+			assert.NoError(t, dec.Decode(&id, &text, &nums, &strs, &js, &char, &dur, &nullDur, &interval, &null, &raw, &flag, &tm, &ptr))
+			assert.Equal(t, int64(1), id)
+			assert.Equal(t, row[1], text)
+			assert.Equal(t, row[2], nums)
+			assert.Equal(t, row[3], strs)
+			assert.Equal(t, row[4], js)
+			assert.Equal(t, row[5], char)
+			assert.Equal(t, row[6], dur)
+			assert.False(t, nullDur.Valid)
+			assert.Equal(t, row[8], interval)
+			assert.Nil(t, null)
+			assert.Equal(t, row[10], raw)
+			assert.True(t, flag)
+			assert.True(t, ts.Equal(tm))
+			assert.Nil(t, ptr)
+
+			_, err := dec.ReadRow()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestCopyDecoder(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		dec := NewCopyDecoder(strings.NewReader("a\\x41\\101\\q\t\\N\t\r\nlast\n\\.\nignored\n"), CopyOptions{})
+		row, err := dec.ReadRow()
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"aAAq", nil, ""}, row)
+
+		var s string
+		assert.NoError(t, dec.Decode(&s))
+		assert.Equal(t, "last", s)
+
+		_, err = dec.ReadRow()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		dec := NewCopyDecoder(strings.NewReader("\"\",,\"multi\nline\",\"q\"\"\"\n\\.\n"), CopyOptions{Format: CopyCSV})
+		row, err := dec.ReadRow()
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"", nil, "multi\nline", `q"`}, row)
+
+		_, err = dec.ReadRow()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		opts := CopyOptions{Delimiter: '|', Null: "NULL"}
+		var buf bytes.Buffer
+		assert.NoError(t, NewCopyEncoder(&buf, opts).Encode("a|b", nil, "NULL"))
+		assert.Equal(t, "a\\|b|NULL|NULL\n", buf.String())
+
+		row, err := NewCopyDecoder(&buf, opts).ReadRow()
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"a|b", nil, nil}, row, "the unescaped NULL token is always NULL")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var n NumberArray[int64]
+		dec := NewCopyDecoder(strings.NewReader("\\N\n1\t2\n"), CopyOptions{})
+		assert.ErrorIs(t, dec.Decode(&n), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, dec.Decode(&n), ErrInvalidCopyData)
+
+		dec = NewCopyDecoder(strings.NewReader("\"open\n"), CopyOptions{Format: CopyCSV})
+		_, err := dec.ReadRow()
+		assert.ErrorIs(t, err, ErrInvalidCopyData)
+
+		var i int8
+		dec = NewCopyDecoder(strings.NewReader("300\n"), CopyOptions{})
+		assert.ErrorIs(t, dec.Decode(&i), ErrInvalidCopyData)
+
+		_, err = AppendCopyRow(nil, CopyOptions{}, struct{}{})
+		assert.ErrorIs(t, err, ErrInvalidCopyData)
+	})
+}
@@ -2,7 +2,8 @@ package gosql
 
 import (
 	"database/sql/driver"
-	"strconv"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -15,37 +16,172 @@ const (
 	Second               = Duration(time.Second)
 	Minute               = Duration(time.Minute)
 	Hour                 = Duration(time.Hour)
+	Day                  = 24 * Hour
+	Week                 = 7 * Day
+	Month                = 30 * Day  // Month is the fixed 30 days period
+	Year                 = 365 * Day // Year is the fixed 365 days period
 )
 
+var durationUnits = []struct {
+	name string
+	unit Duration
+}{
+	// Longer names go first to match `mo` and `ms` before `m`
+	{"mo", Month},
+	{"ms", Millisecond},
+	{"us", Microsecond},
+	{"µs", Microsecond}, // U+00B5 micro sign
+	{"μs", Microsecond}, // U+03BC greek letter mu
+	{"ns", Nanosecond},
+	{"y", Year},
+	{"w", Week},
+	{"d", Day},
+	{"h", Hour},
+	{"m", Minute},
+	{"s", Second},
+}
+
+// DurationParseError describes the position of the invalid duration string
+type DurationParseError struct {
+	Input string
+	Pos   int
+	Msg   string
+}
+
+// Error implements the error interface
+func (e *DurationParseError) Error() string {
+	return fmt.Sprintf("%s %q: %s at position %d", ErrInvalidDuration.Error(), e.Input, e.Msg, e.Pos)
+}
+
+// Unwrap returns ErrInvalidDuration
+func (e *DurationParseError) Unwrap() error {
+	return ErrInvalidDuration
+}
+
 // ParseDuration parses a duration string.
+//
+// A duration string is a possibly signed sequence of decimal numbers,
+// each with optional fraction and a unit suffix, such as "1w2d12h", "-1.5d"
+// or "1h 30m". Valid units are "y" (365 days), "mo" (30 days), "w", "d",
+// "h", "m", "s", "ms", "us" (or "µs"), "ns". Whitespace between the parts
+// is ignored, the empty string is the zero duration.
 func ParseDuration(s string) (Duration, error) {
 	var (
-		additional time.Duration
-		dur        time.Duration
-		err        error
+		pos   = skipDurationSpaces(s, 0)
+		neg   bool
+		total uint64
 	)
-	if strings.Contains(s, "w") {
-		v := strings.Split(s, "w")
-		i, err := strconv.Atoi(v[0])
-		if err != nil {
-			return 0, err
+	if pos == len(s) {
+		return 0, nil
+	}
+	if s[pos] == '-' || s[pos] == '+' {
+		neg = s[pos] == '-'
+		pos = skipDurationSpaces(s, pos+1)
+	}
+	if s[pos:] == "0" {
+		return 0, nil
+	}
+	if pos == len(s) {
+		return 0, &DurationParseError{Input: s, Pos: pos, Msg: "missing value"}
+	}
+	for pos < len(s) {
+		// Number with optional fraction
+		start := pos
+		var intPart, frac, scale uint64 = 0, 0, 1
+		for ; pos < len(s) && s[pos] >= '0' && s[pos] <= '9'; pos++ {
+			if intPart > (1<<63)/10 {
+				return 0, &DurationParseError{Input: s, Pos: start, Msg: "value overflow"}
+			}
+			intPart = intPart*10 + uint64(s[pos]-'0')
+		}
+		digits := pos - start
+		if pos < len(s) && s[pos] == '.' {
+			pos++
+			for ; pos < len(s) && s[pos] >= '0' && s[pos] <= '9'; pos++ {
+				if scale < 1e18 { // ignore the precision beyond the nanoseconds
+					frac = frac*10 + uint64(s[pos]-'0')
+					scale *= 10
+				}
+				digits++
+			}
+		}
+		if digits == 0 {
+			return 0, &DurationParseError{Input: s, Pos: start, Msg: "expected number"}
+		}
+
+		// Unit of the value
+		unitPos := pos
+		unit := Duration(0)
+		for _, u := range durationUnits {
+			if strings.HasPrefix(s[pos:], u.name) {
+				unit, pos = u.unit, pos+len(u.name)
+				break
+			}
+		}
+		if unit == 0 {
+			if unitPos == len(s) {
+				return 0, &DurationParseError{Input: s, Pos: unitPos, Msg: "missing unit"}
+			}
+			return 0, &DurationParseError{Input: s, Pos: unitPos, Msg: "unknown unit"}
+		}
+
+		// Add the value to the total with overflow check
+		if intPart > (1<<63)/uint64(unit) {
+			return 0, &DurationParseError{Input: s, Pos: start, Msg: "value overflow"}
+		}
+		value := intPart * uint64(unit)
+		if frac > 0 {
+			value += uint64(float64(frac) * (float64(unit) / float64(scale)))
 		}
-		additional = time.Duration(i) * 7 * 24 * time.Hour
-		s = v[1]
+		if value > 1<<63 || total > 1<<63-value {
+			return 0, &DurationParseError{Input: s, Pos: start, Msg: "value overflow"}
+		}
+		total += value
+		pos = skipDurationSpaces(s, pos)
+	}
+	if neg {
+		return -Duration(total), nil
+	}
+	if total > 1<<63-1 {
+		return 0, &DurationParseError{Input: s, Pos: 0, Msg: "value overflow"}
+	}
+	return Duration(total), nil
+}
+
+// ParseInterval parses the PostgreSQL interval in any IntervalStyle
+// (postgres, postgres_verbose, sql_standard, iso_8601) or ISO 8601 duration
+// like "1 day 02:03:04", "@ 1 hour 30 mins ago", "1-2 3 4:05:06" or "P1DT2H".
+// Every month is converted as Month (30 days) like PostgreSQL compares intervals,
+// so "1 year" is 360 days and "11 mons" is always less than "1 year".
+func ParseInterval(s string) (Duration, error) {
+	parts, err := parseIntervalParts(s)
+	if err != nil {
+		return 0, err
+	}
+	d, ok := parts.duration()
+	if !ok {
+		return 0, &DurationParseError{Input: s, Pos: 0, Msg: "value overflow"}
 	}
-	if strings.Contains(s, "d") {
-		v := strings.Split(s, "d")
-		i, err := strconv.Atoi(v[0])
-		if err != nil {
-			return 0, err
+	return d, nil
+}
+
+// parseDurationText parses the duration in the ParseDuration format
+// and falls back to the interval formats
+func parseDurationText(s string) (Duration, error) {
+	d, err := ParseDuration(s)
+	if err != nil {
+		if dur, ierr := ParseInterval(s); ierr == nil {
+			return dur, nil
 		}
-		additional = time.Duration(i) * 24 * time.Hour
-		s = v[1]
 	}
-	if s != "" {
-		dur, err = time.ParseDuration(s)
+	return d, err
+}
+
+func skipDurationSpaces(s string, pos int) int {
+	for pos < len(s) && (s[pos] == ' ' || s[pos] == '\t' || s[pos] == '\n' || s[pos] == '\r') {
+		pos++
 	}
-	return Duration(dur + additional), err
+	return pos
 }
 
 // Duration is a wrapper around time.Duration that allows us to
@@ -56,35 +192,41 @@ func (d Duration) String() string {
 	return time.Duration(d).String()
 }
 
+// Compact returns the duration in the compact extended format like `1d12h`.
+func (d Duration) Compact() string {
+	return CompactDurationFormat.Format(d)
+}
+
 // Duration returns the time.Duration value.
 func (d Duration) Duration() time.Duration { return time.Duration(d) }
 
 // Scan implements the Scanner interface.
+// Numbers are interpreted as nanoseconds, strings are durations or PostgreSQL
+// intervals, so the bare numeric string is the number of seconds like "10"::interval.
 func (d *Duration) Scan(value any) error {
-	switch v := value.(type) {
-	case int64:
-		*d = Duration(time.Duration(v))
-	case []byte:
-		dur, err := ParseDuration(string(v))
-		if err != nil {
-			return err
-		}
-		*d = dur
+	var (
+		v   Duration
+		err error
+	)
+	switch s := value.(type) {
 	case string:
-		dur, err := ParseDuration(v)
-		if err != nil {
-			return err
-		}
-		*d = dur
+		v, err = parseDurationText(s)
+	case []byte:
+		v, err = parseDurationText(string(s))
 	default:
-		return ErrInvalidScanValue
+		v, err = scanDuration(value, Nanosecond)
+	}
+	if err != nil {
+		return err
 	}
+	*d = v
 	return nil
 }
 
 // Value implements the driver Valuer interface.
+// The output format is defined by SetDurationFormatter.
 func (d Duration) Value() (driver.Value, error) {
-	return time.Duration(d).String(), nil
+	return currentDurationFormatter().Format(d), nil
 }
 
 // Nanoseconds returns the duration as an integer nanosecond count.
@@ -126,17 +268,19 @@ func (d Duration) Round(m Duration) Duration {
 func (d Duration) Abs() Duration { return Duration(time.Duration(d).Abs()) }
 
 // MarshalJSON implements the json.Marshaler interface.
+// The output format is defined by SetDurationFormatter.
 func (d Duration) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + d.String() + `"`), nil
+	return json.Marshal(currentDurationFormatter().Format(d))
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (d *Duration) UnmarshalJSON(data []byte) error {
-	if len(data) < 2 {
+	if len(data) < 2 || data[0] != '"' {
 		return ErrInvalidDecodeValue
 	}
-	if data[0] != '"' || data[len(data)-1] != '"' {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
 		return ErrInvalidDecodeValue
 	}
-	return d.Scan(string(data[1 : len(data)-1]))
+	return d.Scan(s)
 }
@@ -0,0 +1,242 @@
+package gosql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DurationUnits is the set of units used by DurationFormat
+type DurationUnits uint16
+
+// Duration units
+const (
+	DurationUnitNanosecond DurationUnits = 1 << iota
+	DurationUnitMicrosecond
+	DurationUnitMillisecond
+	DurationUnitSecond
+	DurationUnitMinute
+	DurationUnitHour
+	DurationUnitDay
+	DurationUnitWeek
+	DurationUnitMonth
+	DurationUnitYear
+
+	// DurationUnitsDefault is the set of units with exact length
+	DurationUnitsDefault = DurationUnitWeek | DurationUnitDay | DurationUnitHour |
+		DurationUnitMinute | DurationUnitSecond | DurationUnitMillisecond |
+		DurationUnitMicrosecond | DurationUnitNanosecond
+)
+
+var durationFormatUnits = []struct {
+	flag DurationUnits
+	name string
+	unit Duration
+}{
+	{DurationUnitYear, "y", Year},
+	{DurationUnitMonth, "mo", Month},
+	{DurationUnitWeek, "w", Week},
+	{DurationUnitDay, "d", Day},
+	{DurationUnitHour, "h", Hour},
+	{DurationUnitMinute, "m", Minute},
+	{DurationUnitSecond, "s", Second},
+	{DurationUnitMillisecond, "ms", Millisecond},
+	{DurationUnitMicrosecond, "us", Microsecond},
+	{DurationUnitNanosecond, "ns", Nanosecond},
+}
+
+// DurationFormatter converts the duration into the text
+type DurationFormatter interface {
+	Format(d Duration) string
+}
+
+// DurationFormat defines the compact extended format of the duration
+// like `2w` or `1d12h` which is accepted by ParseDuration
+type DurationFormat struct {
+	// Units used in the output, DurationUnitsDefault if empty.
+	// The remainder less than the smallest unit is written as its fraction.
+	Units DurationUnits
+
+	// Precision rounds the duration to the multiple of the value if positive
+	Precision Duration
+}
+
+// CompactDurationFormat writes durations with the default units set
+var CompactDurationFormat = DurationFormat{}
+
+// Format the duration
+func (f DurationFormat) Format(d Duration) string {
+	units := f.Units
+	if units == 0 {
+		units = DurationUnitsDefault
+	}
+	if f.Precision > 0 {
+		d = d.Round(f.Precision)
+	}
+
+	var (
+		buff     strings.Builder
+		rest     = uint64(d)
+		smallest = -1
+	)
+	if d < 0 {
+		buff.WriteByte('-')
+		rest = uint64(-d)
+	}
+	for i, u := range durationFormatUnits {
+		if units&u.flag != 0 {
+			smallest = i
+		}
+	}
+	if smallest < 0 {
+		return time.Duration(d).String()
+	}
+	for i, u := range durationFormatUnits[:smallest+1] {
+		if units&u.flag == 0 {
+			continue
+		}
+		value := rest / uint64(u.unit)
+		rest -= value * uint64(u.unit)
+		if i < smallest {
+			if value > 0 {
+				buff.WriteString(strconv.FormatUint(value, 10))
+				buff.WriteString(u.name)
+			}
+			continue
+		}
+		if value == 0 && rest == 0 && buff.Len() > 0 {
+			break
+		}
+		buff.WriteString(strconv.FormatUint(value, 10))
+		if rest > 0 {
+			frac := strconv.FormatFloat(float64(rest)/float64(u.unit), 'f', -1, 64)
+			if strings.HasPrefix(frac, "0.") {
+				buff.WriteString(frac[1:])
+			}
+		}
+		buff.WriteString(u.name)
+	}
+	return buff.String()
+}
+
+type goDurationFormat struct{}
+
+func (goDurationFormat) Format(d Duration) string { return time.Duration(d).String() }
+
+// GoDurationFormat writes durations like time.Duration.String
+var GoDurationFormat DurationFormatter = goDurationFormat{}
+
+type iso8601DurationFormat struct{}
+
+// Format the duration like `P1DT2H3M4.5S`, negative components are signed
+// like in the iso_8601 IntervalStyle of PostgreSQL: `P-1DT-2H`
+func (iso8601DurationFormat) Format(d Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var (
+		buff       strings.Builder
+		sign       string
+		days, rest = splitDurationDays(d)
+	)
+	if d < 0 {
+		sign = "-"
+	}
+	buff.WriteByte('P')
+	if days > 0 {
+		buff.WriteString(sign + strconv.FormatUint(days, 10) + "D")
+	}
+	if rest == 0 {
+		return buff.String()
+	}
+	buff.WriteByte('T')
+	if h := rest / uint64(Hour); h > 0 {
+		buff.WriteString(sign + strconv.FormatUint(h, 10) + "H")
+	}
+	if m := rest % uint64(Hour) / uint64(Minute); m > 0 {
+		buff.WriteString(sign + strconv.FormatUint(m, 10) + "M")
+	}
+	if sec := rest % uint64(Minute); sec > 0 {
+		buff.WriteString(sign + strconv.FormatUint(sec/uint64(Second), 10))
+		buff.WriteString(formatDurationFraction(sec % uint64(Second)))
+		buff.WriteByte('S')
+	}
+	return buff.String()
+}
+
+// ISO8601DurationFormat writes durations in ISO 8601 format like `P1DT2H3M4.5S`
+var ISO8601DurationFormat DurationFormatter = iso8601DurationFormat{}
+
+type postgresIntervalFormat struct{}
+
+// Format the duration like `1 day 02:03:04.5` or `-2 days -01:00:00`
+func (postgresIntervalFormat) Format(d Duration) string {
+	var (
+		buff       strings.Builder
+		sign       string
+		days, rest = splitDurationDays(d)
+	)
+	if d < 0 {
+		sign = "-"
+	}
+	if days > 0 {
+		buff.WriteString(sign + strconv.FormatUint(days, 10))
+		if days == 1 && d > 0 {
+			buff.WriteString(" day")
+		} else {
+			buff.WriteString(" days")
+		}
+		if rest == 0 {
+			return buff.String()
+		}
+		buff.WriteByte(' ')
+	}
+	buff.WriteString(sign)
+	buff.WriteString(fmt.Sprintf("%02d:%02d:%02d",
+		rest/uint64(Hour), rest%uint64(Hour)/uint64(Minute), rest%uint64(Minute)/uint64(Second)))
+	buff.WriteString(formatDurationFraction(rest % uint64(Second)))
+	return buff.String()
+}
+
+// PostgresIntervalFormat writes durations in the postgres IntervalStyle like `1 day 02:03:04.5`
+var PostgresIntervalFormat DurationFormatter = postgresIntervalFormat{}
+
+// splitDurationDays returns the absolute number of days and the rest of the day
+func splitDurationDays(d Duration) (days, rest uint64) {
+	abs := uint64(d)
+	if d < 0 {
+		abs = uint64(-d)
+	}
+	return abs / uint64(Day), abs % uint64(Day)
+}
+
+// formatDurationFraction returns the fraction of the second like `.5` or empty string
+func formatDurationFraction(nanos uint64) string {
+	if nanos == 0 {
+		return ""
+	}
+	return "." + strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+}
+
+var durationFormatter atomic.Value
+
+// SetDurationFormatter defines the output format of Duration.Value and Duration.MarshalJSON,
+// GoDurationFormat is used by default. Use PostgresIntervalFormat or ISO8601DurationFormat
+// to store durations in the `interval` columns.
+//
+//	gosql.SetDurationFormatter(gosql.CompactDurationFormat)
+func SetDurationFormatter(f DurationFormatter) {
+	if f == nil {
+		f = GoDurationFormat
+	}
+	durationFormatter.Store(&f)
+}
+
+func currentDurationFormatter() DurationFormatter {
+	if f, _ := durationFormatter.Load().(*DurationFormatter); f != nil {
+		return *f
+	}
+	return GoDurationFormat
+}
@@ -0,0 +1,76 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationFormat(t *testing.T) {
+	tests := []struct {
+		format DurationFormat
+		value  Duration
+		target string
+	}{
+		{CompactDurationFormat, 2 * Week, "2w"},
+		{CompactDurationFormat, Day + 12*Hour, "1d12h"},
+		{CompactDurationFormat, Hour + 30*Minute + 1500*Millisecond, "1h30m1s500ms"},
+		{CompactDurationFormat, -Day, "-1d"},
+		{CompactDurationFormat, 0, "0ns"},
+		{CompactDurationFormat, Nanosecond, "1ns"},
+		{DurationFormat{Units: DurationUnitDay | DurationUnitHour}, Day + 12*Hour + 30*Minute, "1d12.5h"},
+		{DurationFormat{Units: DurationUnitHour | DurationUnitSecond}, 0, "0s"},
+		{DurationFormat{Units: DurationUnitDay}, 3 * Week, "21d"},
+		{DurationFormat{Units: DurationUnitYear | DurationUnitMonth | DurationUnitDay}, Year + Month + Day, "1y1mo1d"},
+		{DurationFormat{Precision: Second}, Minute + 1600*Millisecond, "1m2s"},
+		{DurationFormat{Precision: Hour}, 2*Day + 20*Minute, "2d"},
+	}
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			assert.Equal(t, test.target, test.format.Format(test.value))
+			d, err := ParseDuration(test.target)
+			if assert.NoError(t, err) && test.format.Precision == 0 {
+				assert.Equal(t, test.value, d, "round-trip")
+			}
+		})
+	}
+
+	t.Run("formatter", func(t *testing.T) {
+		defer SetDurationFormatter(nil)
+
+		d := 2 * Week
+		v, err := d.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "336h0m0s", v)
+		assert.Equal(t, "2w", d.Compact())
+
+		SetDurationFormatter(CompactDurationFormat)
+		v, err = d.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "2w", v)
+		data, err := d.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `"2w"`, string(data))
+		assert.Equal(t, "336h0m0s", d.String())
+	})
+
+	t.Run("json_escape", func(t *testing.T) {
+		defer SetDurationFormatter(nil)
+
+		SetDurationFormatter(quotedDurationFormat{})
+		data, err := json.Marshal(2 * Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, `"\"2h0m0s\"\\"`, string(data))
+		assert.True(t, json.Valid(data))
+
+		var s string
+		assert.NoError(t, json.Unmarshal(data, &s))
+		assert.Equal(t, `"2h0m0s"\`, s)
+	})
+}
+
+// quotedDurationFormat writes the duration with characters escaped in JSON
+type quotedDurationFormat struct{}
+
+func (quotedDurationFormat) Format(d Duration) string { return `"` + d.String() + `"\` }
@@ -0,0 +1,179 @@
+package gosql
+
+import (
+	"database/sql/driver"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationStorage defines the representation of the StoredDuration in the database
+type DurationStorage interface {
+	// EncodeDuration returns the database value of the duration
+	EncodeDuration(d Duration) driver.Value
+
+	// DurationUnit of the numeric database values
+	DurationUnit() Duration
+}
+
+// Predefined storages of the StoredDuration
+type (
+	// DurationStorageNanoseconds stores the integer number of nanoseconds
+	DurationStorageNanoseconds struct{}
+
+	// DurationStorageMilliseconds stores the integer number of milliseconds
+	DurationStorageMilliseconds struct{}
+
+	// DurationStorageSeconds stores the integer number of seconds
+	DurationStorageSeconds struct{}
+
+	// DurationStorageFloatSeconds stores the float number of seconds
+	DurationStorageFloatSeconds struct{}
+
+	// DurationStorageText stores the text in the format defined by SetDurationFormatter
+	DurationStorageText struct{}
+)
+
+func (DurationStorageNanoseconds) EncodeDuration(d Duration) driver.Value  { return int64(d) }
+func (DurationStorageMilliseconds) EncodeDuration(d Duration) driver.Value { return d.Milliseconds() }
+func (DurationStorageSeconds) EncodeDuration(d Duration) driver.Value      { return int64(d / Second) }
+func (DurationStorageFloatSeconds) EncodeDuration(d Duration) driver.Value { return d.Seconds() }
+func (DurationStorageText) EncodeDuration(d Duration) driver.Value {
+	return currentDurationFormatter().Format(d)
+}
+
+func (DurationStorageNanoseconds) DurationUnit() Duration  { return Nanosecond }
+func (DurationStorageMilliseconds) DurationUnit() Duration { return Millisecond }
+func (DurationStorageSeconds) DurationUnit() Duration      { return Second }
+func (DurationStorageFloatSeconds) DurationUnit() Duration { return Second }
+func (DurationStorageText) DurationUnit() Duration         { return Nanosecond }
+
+// StoredDuration is the Duration with the database representation defined by S.
+// Scan accepts any form: numbers in the storage unit, numeric strings,
+// duration strings and PostgreSQL intervals.
+//
+//	type Model struct {
+//		Timeout gosql.DurationSeconds // INT seconds
+//		TTL     gosql.DurationMilliseconds // UInt64 ms
+//	}
+type StoredDuration[S DurationStorage] Duration
+
+// Predefined duration storage variants
+type (
+	DurationNanoseconds  = StoredDuration[DurationStorageNanoseconds]
+	DurationMilliseconds = StoredDuration[DurationStorageMilliseconds]
+	DurationSeconds      = StoredDuration[DurationStorageSeconds]
+	DurationFloatSeconds = StoredDuration[DurationStorageFloatSeconds]
+	DurationText         = StoredDuration[DurationStorageText]
+)
+
+// Duration returns the Duration value
+func (d StoredDuration[S]) Duration() Duration { return Duration(d) }
+
+// String implements the Stringer interface
+func (d StoredDuration[S]) String() string { return Duration(d).String() }
+
+// Value implements the driver.Valuer interface
+func (d StoredDuration[S]) Value() (driver.Value, error) {
+	var storage S
+	return storage.EncodeDuration(Duration(d)), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (d *StoredDuration[S]) Scan(value any) error {
+	var storage S
+	v, err := scanDuration(value, storage.DurationUnit())
+	if err != nil {
+		return err
+	}
+	*d = StoredDuration[S](v)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler like Duration
+func (d StoredDuration[S]) MarshalJSON() ([]byte, error) {
+	return Duration(d).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler,
+// accepts the duration string or the number in the storage unit
+func (d *StoredDuration[S]) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		if string(data) == "null" {
+			return ErrNullValueNotAllowed
+		}
+		return d.Scan(data)
+	}
+	var v Duration
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*d = StoredDuration[S](v)
+	return nil
+}
+
+// scanDuration decodes the database value, numbers are interpreted in the unit
+func scanDuration(value any, unit Duration) (Duration, error) {
+	switch v := value.(type) {
+	case int64:
+		return durationOfInt(v, unit)
+	case int32:
+		return durationOfInt(int64(v), unit)
+	case int:
+		return durationOfInt(int64(v), unit)
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, ErrInvalidDuration
+		}
+		return durationOfInt(int64(v), unit)
+	case uint32:
+		return durationOfInt(int64(v), unit)
+	case float64:
+		return durationOfFloat(v, unit)
+	case float32:
+		return durationOfFloat(float64(v), unit)
+	case time.Duration:
+		return Duration(v), nil
+	case Duration:
+		return v, nil
+	case []byte:
+		return scanDurationText(string(v), unit)
+	case string:
+		return scanDurationText(v, unit)
+	}
+	return 0, ErrInvalidScanValue
+}
+
+// scanDurationText decodes the numeric string in the unit or the duration text
+func scanDurationText(s string, unit Duration) (Duration, error) {
+	if num := strings.TrimSpace(s); num != "" && strings.IndexFunc(num, isDurationTextChar) < 0 {
+		if v, err := strconv.ParseInt(num, 10, 64); err == nil {
+			return durationOfInt(v, unit)
+		}
+		if v, err := strconv.ParseFloat(num, 64); err == nil {
+			return durationOfFloat(v, unit)
+		}
+	}
+	return parseDurationText(s)
+}
+
+// isDurationTextChar returns true for the chars which are not the part of the plain number
+func isDurationTextChar(r rune) bool {
+	return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+' && r != 'e' && r != 'E'
+}
+
+func durationOfInt(v int64, unit Duration) (Duration, error) {
+	if v != 0 && (v*int64(unit))/int64(unit) != v {
+		return 0, ErrInvalidDuration
+	}
+	return Duration(v) * unit, nil
+}
+
+func durationOfFloat(v float64, unit Duration) (Duration, error) {
+	v = math.Round(v * float64(unit))
+	if math.IsNaN(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, ErrInvalidDuration
+	}
+	return Duration(v), nil
+}
@@ -0,0 +1,98 @@
+package gosql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoredDuration(t *testing.T) {
+	d := 90*Second + 500*Millisecond
+
+	t.Run("value", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			value  driver.Valuer
+			target driver.Value
+		}{
+			{"nanoseconds", DurationNanoseconds(d), int64(90_500_000_000)},
+			{"milliseconds", DurationMilliseconds(d), int64(90_500)},
+			{"seconds", DurationSeconds(d), int64(90)},
+			{"float_seconds", DurationFloatSeconds(d), 90.5},
+			{"text", DurationText(d), "1m30.5s"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				v, err := test.value.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.target, v)
+			})
+		}
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			scan   func(v any) (Duration, error)
+			value  any
+			target Duration
+		}{
+			{"seconds:int64", scanAs[DurationStorageSeconds], int64(30), 30 * Second},
+			{"seconds:bytes", scanAs[DurationStorageSeconds], []byte("30"), 30 * Second},
+			{"seconds:float", scanAs[DurationStorageSeconds], 1.5, 1500 * Millisecond},
+			{"seconds:text", scanAs[DurationStorageSeconds], "1m", Minute},
+			{"seconds:interval", scanAs[DurationStorageSeconds], "00:01:00", Minute},
+			{"milliseconds:uint64", scanAs[DurationStorageMilliseconds], uint64(1500), 1500 * Millisecond},
+			{"milliseconds:string", scanAs[DurationStorageMilliseconds], "1500", 1500 * Millisecond},
+			{"float_seconds:float", scanAs[DurationStorageFloatSeconds], 0.25, 250 * Millisecond},
+			{"float_seconds:string", scanAs[DurationStorageFloatSeconds], "0.25", 250 * Millisecond},
+			{"nanoseconds:int64", scanAs[DurationStorageNanoseconds], int64(5), 5 * Nanosecond},
+			{"text:string", scanAs[DurationStorageText], "1d", Day},
+			{"text:duration", scanAs[DurationStorageText], time.Hour, Hour},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				v, err := test.scan(test.value)
+				assert.NoError(t, err)
+				assert.Equal(t, test.target, v)
+			})
+		}
+
+		var v DurationSeconds
+		assert.ErrorIs(t, v.Scan(nil), ErrInvalidScanValue)
+		assert.ErrorIs(t, v.Scan(int64(1<<62)), ErrInvalidDuration)
+		assert.ErrorIs(t, v.Scan("invalid"), ErrInvalidDuration)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var v struct {
+			Timeout DurationSeconds
+			TTL     DurationMilliseconds
+		}
+		assert.NoError(t, json.Unmarshal([]byte(`{"Timeout":30,"TTL":"1s"}`), &v))
+		assert.Equal(t, 30*Second, v.Timeout.Duration())
+		assert.Equal(t, Second, v.TTL.Duration())
+
+		data, err := json.Marshal(v)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Timeout":"30s","TTL":"1s"}`, string(data))
+		assert.Error(t, json.Unmarshal([]byte(`{"Timeout":null}`), &v))
+	})
+
+	t.Run("duration:numeric_string", func(t *testing.T) {
+		var v Duration
+		assert.NoError(t, v.Scan([]byte("1000")))
+		assert.Equal(t, 1000*Second, v, "the interval in seconds")
+		assert.NoError(t, v.Scan(1.5e9))
+		assert.Equal(t, 1500*Millisecond, v)
+	})
+}
+
+func scanAs[S DurationStorage](value any) (Duration, error) {
+	var v StoredDuration[S]
+	err := v.Scan(value)
+	return v.Duration(), err
+}
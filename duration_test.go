@@ -20,6 +20,21 @@ func TestDuration(t *testing.T) {
 		{"1h", Hour},
 		{"1d", Duration(24 * time.Hour)},
 		{"1w", Duration(7 * 24 * time.Hour)},
+		{"2w3d", 2*Week + 3*Day},
+		{"1w2d12h", Week + 2*Day + 12*Hour},
+		{"1.5d", Day + 12*Hour},
+		{"-1d", -Day},
+		{"+1h30m", Hour + 30*Minute},
+		{"1h 30m 15s", Hour + 30*Minute + 15*Second},
+		{" 1d ", Day},
+		{"1y2mo", Year + 2*Month},
+		{"1m30s500ms", Minute + 30*Second + 500*Millisecond},
+		{"1µs", Microsecond},
+		{"1μs", Microsecond},
+		{"1.5us", Microsecond + 500*Nanosecond},
+		{".5s", 500 * Millisecond},
+		{"0", 0},
+		{"-0", 0},
 	}
 
 	for _, test := range tests {
@@ -43,6 +58,18 @@ func TestDuration(t *testing.T) {
 		})
 	}
 
+	t.Run("scan:numeric_string", func(t *testing.T) {
+		// the bare number is the interval in seconds
+		var d Duration
+		assert.NoError(t, d.Scan("10"))
+		assert.Equal(t, 10*Second, d)
+		assert.NoError(t, d.Scan([]byte("1500")))
+		assert.Equal(t, 25*Minute, d)
+		assert.NoError(t, d.Scan(int64(10)))
+		assert.Equal(t, 10*Nanosecond, d)
+		assert.ErrorIs(t, d.Scan([]byte("3600000000000")), ErrInvalidDuration, "too many seconds")
+	})
+
 	t.Run("scan:invalid", func(t *testing.T) {
 		var d Duration
 		assert.Error(t, d.Scan("invalid"))
@@ -51,6 +78,36 @@ func TestDuration(t *testing.T) {
 		assert.Error(t, d.Scan(any(nil)))
 	})
 
+	t.Run("parse:errors", func(t *testing.T) {
+		tests := []struct {
+			value string
+			pos   int
+			msg   string
+		}{
+			{"d", 0, "expected number"},
+			{"1", 1, "missing unit"},
+			{"1d2", 3, "missing unit"},
+			{"1x", 1, "unknown unit"},
+			{"1d 2q", 4, "unknown unit"},
+			{"1h-2m", 2, "expected number"},
+			{"-", 1, "missing value"},
+			{"9999999999999999999h", 0, "value overflow"},
+			{"300y", 0, "value overflow"},
+		}
+		for _, test := range tests {
+			_, err := ParseDuration(test.value)
+			var perr *DurationParseError
+			if assert.ErrorAs(t, err, &perr, test.value) {
+				assert.Equal(t, test.pos, perr.Pos, test.value)
+				assert.Equal(t, test.msg, perr.Msg, test.value)
+			}
+			assert.ErrorIs(t, err, ErrInvalidDuration)
+		}
+		d, err := ParseDuration("")
+		assert.NoError(t, err)
+		assert.Equal(t, Duration(0), d)
+	})
+
 	t.Run("string", func(t *testing.T) {
 		assert.Equal(t, "1ns", Nanosecond.String())
 		assert.Equal(t, "1µs", Microsecond.String())
@@ -14,4 +14,22 @@ var (
 	ErrInvalidSetValue     = errors.New("invalid field set value")
 	ErrNullValueNotAllowed = errors.New("nil value not allowed")
 	ErrInvalidDecodeValue  = errors.New("invalid decode value")
+	ErrInvalidArrayLiteral = errors.New("invalid array literal")
+	ErrValueTooLong        = errors.New("value too long")
+	ErrInvalidEnumValue    = errors.New("invalid enum value")
+	ErrInvalidTransition   = errors.New("invalid state transition")
+	ErrInvalidDuration     = errors.New("invalid duration")
+	ErrInvalidBinaryArray  = errors.New("invalid binary array")
+	ErrInvalidCopyData     = errors.New("invalid copy data")
+	ErrJSONTooLarge        = errors.New("json document too large")
+	ErrJSONTooDeep         = errors.New("json document too deep")
+	ErrJSONUnknownField    = errors.New("unknown field")
+	ErrInvalidJSONPatch    = errors.New("invalid json patch")
+	ErrJSONPatchTestFailed = errors.New("json patch test failed")
+	ErrInvalidJSONPath     = errors.New("invalid json path")
+	ErrJSONPathNotFound    = errors.New("value not found")
+	ErrJSONPathType        = errors.New("unexpected value type")
+
+	ErrUnsupportedCompression = errors.New("unsupported json compression")
+	ErrInvalidCompressedJSON  = errors.New("invalid compressed json")
 )
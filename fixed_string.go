@@ -0,0 +1,140 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// FixedStringSize defines the length of the FixedString in characters
+type FixedStringSize interface {
+	FixedSize() int
+}
+
+// FixedStringPadder is optionally implemented by the FixedStringSize type
+// to define the padding character, the space is used by default
+type FixedStringPadder interface {
+	FixedPad() byte
+}
+
+// Predefined sizes of the FixedString
+type (
+	FixedSize2  struct{}
+	FixedSize3  struct{}
+	FixedSize4  struct{}
+	FixedSize8  struct{}
+	FixedSize16 struct{}
+	FixedSize32 struct{}
+	FixedSize64 struct{}
+)
+
+func (FixedSize2) FixedSize() int  { return 2 }
+func (FixedSize3) FixedSize() int  { return 3 }
+func (FixedSize4) FixedSize() int  { return 4 }
+func (FixedSize8) FixedSize() int  { return 8 }
+func (FixedSize16) FixedSize() int { return 16 }
+func (FixedSize32) FixedSize() int { return 32 }
+func (FixedSize64) FixedSize() int { return 64 }
+
+// FixedString is the string of the fixed length like `CHAR(n)` or ClickHouse `FixedString(N)`
+//
+// The value is stored without padding, Scan trims trailing spaces and zero bytes
+// and Value pads the string to the fixed length in characters.
+// ClickHouse `FixedString(N)` is N bytes, use BytesValue for it.
+//
+//	type CurrencyCode = gosql.FixedString[gosql.FixedSize3]
+type FixedString[S FixedStringSize] string
+
+// Size of the fixed string in characters
+func (f FixedString[S]) Size() int {
+	var size S
+	return size.FixedSize()
+}
+
+// String value without padding
+func (f FixedString[S]) String() string {
+	return string(f)
+}
+
+// Value implements the driver.Valuer interface, fixed string field
+func (f FixedString[S]) Value() (driver.Value, error) {
+	var (
+		size  S
+		count = utf8.RuneCountInString(string(f))
+	)
+	if count > size.FixedSize() {
+		return nil, ErrValueTooLong
+	}
+	return string(f) + string(bytes.Repeat([]byte{fixedStringPad[S]()}, size.FixedSize()-count)), nil
+}
+
+// BytesValue returns the string padded with zero bytes to the fixed size in bytes
+// like ClickHouse `FixedString(N)`
+func (f FixedString[S]) BytesValue() (string, error) {
+	var size S
+	if len(f) > size.FixedSize() {
+		return "", ErrValueTooLong
+	}
+	return string(f) + strings.Repeat("\x00", size.FixedSize()-len(f)), nil
+}
+
+// Scan implements the sql.Scanner interface, fixed string field
+func (f *FixedString[S]) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		return f.set(v)
+	case []byte:
+		return f.set(string(v))
+	case nil:
+		return ErrNullValueNotAllowed
+	}
+	return ErrInvalidScan
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f FixedString[S]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(f))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *FixedString[S]) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return f.set(s)
+}
+
+func (f *FixedString[S]) set(v string) error {
+	var size S
+	v = trimFixedStringPad(v, fixedStringPad[S]())
+	if utf8.RuneCountInString(v) > size.FixedSize() {
+		return ErrValueTooLong
+	}
+	*f = FixedString[S](v)
+	return nil
+}
+
+// fixedStringPad returns the padding byte of the size type
+func fixedStringPad[S FixedStringSize]() byte {
+	var size S
+	if padder, ok := any(size).(FixedStringPadder); ok {
+		return padder.FixedPad()
+	}
+	return ' '
+}
+
+// trimFixedStringPad trims trailing spaces, zero bytes and the pad byte,
+// the pad byte which completes the last UTF-8 character is kept
+func trimFixedStringPad(v string, pad byte) string {
+	end := len(v)
+	for end > 0 && (v[end-1] == ' ' || v[end-1] == 0 || v[end-1] == pad) {
+		end--
+	}
+	for end < len(v) && pad >= utf8.RuneSelf && v[end] == pad && !utf8.ValidString(v[:end]) {
+		end++
+	}
+	return v[:end]
+}
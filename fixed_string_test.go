@@ -0,0 +1,97 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedSizeZero struct{}
+
+func (fixedSizeZero) FixedSize() int { return 4 }
+func (fixedSizeZero) FixedPad() byte { return 0 }
+
+type fixedSizeDash struct{}
+
+func (fixedSizeDash) FixedSize() int { return 4 }
+func (fixedSizeDash) FixedPad() byte { return '-' }
+
+type fixedSizeHigh struct{}
+
+func (fixedSizeHigh) FixedSize() int { return 4 }
+func (fixedSizeHigh) FixedPad() byte { return 0xAA }
+
+func TestFixedString(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		var s FixedString[FixedSize8]
+		assert.NoError(t, s.Scan("USD     "))
+		assert.Equal(t, FixedString[FixedSize8]("USD"), s)
+		assert.NoError(t, s.Scan([]byte("ab\x00\x00")))
+		assert.Equal(t, "ab", s.String())
+		assert.NoError(t, s.Scan("ёжик"))
+		assert.Equal(t, "ёжик", s.String())
+		assert.ErrorIs(t, s.Scan("too long value"), ErrValueTooLong)
+		assert.ErrorIs(t, s.Scan(nil), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, s.Scan(1), ErrInvalidScan)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := FixedString[FixedSize3]("EU").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "EU ", v)
+		v, err = FixedString[FixedSize3]("ёж").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "ёж ", v)
+		v, err = FixedString[fixedSizeZero]("ab").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "ab\x00\x00", v)
+		_, err = FixedString[FixedSize2]("abc").Value()
+		assert.ErrorIs(t, err, ErrValueTooLong)
+		assert.Equal(t, 2, FixedString[FixedSize2]("").Size())
+	})
+
+	t.Run("pad", func(t *testing.T) {
+		v, err := FixedString[fixedSizeDash]("ab").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "ab--", v)
+		var s FixedString[fixedSizeDash]
+		assert.NoError(t, s.Scan(v))
+		assert.Equal(t, "ab", s.String())
+
+		v, err = FixedString[fixedSizeHigh]("ab").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "ab\xaa\xaa", v)
+		var h FixedString[fixedSizeHigh]
+		assert.NoError(t, h.Scan(v))
+		assert.Equal(t, "ab", h.String())
+
+		// the pad byte is the last byte of ª (0xC2 0xAA)
+		v, err = FixedString[fixedSizeHigh]("aª").Value()
+		assert.NoError(t, err)
+		assert.NoError(t, h.Scan(v))
+		assert.Equal(t, "aª", h.String())
+	})
+
+	t.Run("bytes_value", func(t *testing.T) {
+		v, err := FixedString[FixedSize4]("EU").BytesValue()
+		assert.NoError(t, err)
+		assert.Equal(t, "EU\x00\x00", v)
+		_, err = FixedString[FixedSize3]("ёж").BytesValue()
+		assert.ErrorIs(t, err, ErrValueTooLong, "4 bytes")
+		v, err = FixedString[FixedSize4]("ёж").BytesValue()
+		assert.NoError(t, err)
+		assert.Equal(t, "ёж", v)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(FixedString[FixedSize4]("ab"))
+		assert.NoError(t, err)
+		assert.Equal(t, `"ab"`, string(data))
+
+		var s FixedString[FixedSize4]
+		assert.NoError(t, json.Unmarshal([]byte(`"ab  "`), &s))
+		assert.Equal(t, FixedString[FixedSize4]("ab"), s)
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"abcde"`), &s), ErrValueTooLong)
+	})
+}
@@ -0,0 +1,125 @@
+package gorm
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// arrayElementType defines the element type names of the array column per dialect
+type arrayElementType struct {
+	postgres   string
+	clickhouse string
+	ydb        string
+}
+
+var stringArrayElementType = arrayElementType{postgres: "text", clickhouse: "String", ydb: "Utf8"}
+
+// numberArrayElementType returns the element types by the kind of T,
+// so named types like `type Score float64` are supported
+func numberArrayElementType[T gosql.Number]() arrayElementType {
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Int8:
+		return arrayElementType{postgres: "smallint", clickhouse: "Int8", ydb: "Int8"}
+	case reflect.Uint8:
+		return arrayElementType{postgres: "smallint", clickhouse: "UInt8", ydb: "Uint8"}
+	case reflect.Int16:
+		return arrayElementType{postgres: "smallint", clickhouse: "Int16", ydb: "Int16"}
+	case reflect.Uint16:
+		return arrayElementType{postgres: "integer", clickhouse: "UInt16", ydb: "Uint16"}
+	case reflect.Int32:
+		return arrayElementType{postgres: "integer", clickhouse: "Int32", ydb: "Int32"}
+	case reflect.Uint32:
+		return arrayElementType{postgres: "bigint", clickhouse: "UInt32", ydb: "Uint32"}
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return arrayElementType{postgres: "bigint", clickhouse: "UInt64", ydb: "Uint64"}
+	case reflect.Float32:
+		return arrayElementType{postgres: "real", clickhouse: "Float32", ydb: "Float"}
+	case reflect.Float64:
+		return arrayElementType{postgres: "double precision", clickhouse: "Float64", ydb: "Double"}
+	}
+	return arrayElementType{postgres: "bigint", clickhouse: "Int64", ydb: "Int64"}
+}
+
+func arrayGormDBDataType(db *gorm.DB, elem arrayElementType) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return elem.postgres + "[]"
+	case "mysql", "mariadb":
+		return "json"
+	case "sqlite", "sqlite3":
+		return "text"
+	case "sqlserver":
+		return "nvarchar(max)"
+	case "ydb":
+		return "List<" + elem.ydb + ">"
+	case "clickhouse":
+		return "Array(" + elem.clickhouse + ")"
+	}
+	return ""
+}
+
+// arrayGormValue returns the array value in the column representation:
+// the array literal on postgres, JSON on mysql/sqlite/sqlserver
+// and the slice bound by the driver on ClickHouse and YDB.
+// The nil array is NULL if nullable or the empty array otherwise.
+func arrayGormValue[T any](db *gorm.DB, elem arrayElementType, arr []T, nullable bool, valuer driver.Valuer) clause.Expr {
+	if arr == nil {
+		if nullable {
+			return clause.Expr{SQL: "NULL"}
+		}
+		arr = []T{}
+	}
+	switch db.Dialector.Name() {
+	case "mysql", "mariadb", "sqlite", "sqlite3", "sqlserver":
+		data, err := json.Marshal(arr)
+		if err != nil {
+			_ = db.AddError(err)
+		}
+		return clause.Expr{SQL: "?", Vars: []any{string(data)}}
+	case "ydb", "clickhouse":
+		return clause.Expr{SQL: "?", Vars: []any{arr}}
+	}
+	value, err := valuer.Value()
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	if db.Dialector.Name() == "postgres" {
+		return clause.Expr{SQL: "CAST(? AS " + elem.postgres + "[])", Vars: []any{value}}
+	}
+	return clause.Expr{SQL: "?", Vars: []any{value}}
+}
+
+// decodeArrayValue decodes the slice of elements or JSON array
+// which are returned by ClickHouse, YDB and JSON columns.
+// Returns false if the value must be decoded as the PostgreSQL array literal.
+func decodeArrayValue[T any](value any) ([]T, bool, error) {
+	var data []byte
+	switch v := value.(type) {
+	case []T:
+		return v, true, nil
+	case []byte:
+		data = bytes.TrimSpace(v)
+	case string:
+		data = bytes.TrimSpace([]byte(v))
+	default:
+		return nil, false, nil
+	}
+	// `[1:2]={a,b}` is the array literal with bounds
+	if len(data) == 0 || data[0] != '[' || bytes.Contains(data, []byte("]=")) {
+		return nil, false, nil
+	}
+	var list []T
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, true, err
+	}
+	if list == nil {
+		list = []T{}
+	}
+	return list, true, nil
+}
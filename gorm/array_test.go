@@ -0,0 +1,130 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+func TestGormArrayDataType(t *testing.T) {
+	field := &schema.Field{Name: "test_field"}
+	tests := []struct {
+		dialectName string
+		strings     string
+		int64s      string
+		int32s      string
+		int16s      string
+		float64s    string
+		float32s    string
+	}{
+		{"postgres", "text[]", "bigint[]", "integer[]", "smallint[]", "double precision[]", "real[]"},
+		{"mysql", "json", "json", "json", "json", "json", "json"},
+		{"sqlite", "text", "text", "text", "text", "text", "text"},
+		{"sqlserver", "nvarchar(max)", "nvarchar(max)", "nvarchar(max)", "nvarchar(max)", "nvarchar(max)", "nvarchar(max)"},
+		{"clickhouse", "Array(String)", "Array(Int64)", "Array(Int32)", "Array(Int16)", "Array(Float64)", "Array(Float32)"},
+		{"ydb", "List<Utf8>", "List<Int64>", "List<Int32>", "List<Int16>", "List<Double>", "List<Float>"},
+		{"unknown_dialect", "", "", "", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run("dialect_"+test.dialectName, func(t *testing.T) {
+			db := createMockDB(test.dialectName)
+			assert.Equal(t, test.strings, StringArray{}.GormDBDataType(db, field))
+			assert.Equal(t, test.strings, NullableStringArray{}.GormDBDataType(db, field))
+			assert.Equal(t, test.int64s, NumberArray[int]{}.GormDBDataType(db, field))
+			assert.Equal(t, test.int32s, NullableNumberArray[int32]{}.GormDBDataType(db, field))
+			assert.Equal(t, test.int16s, OrderedNumberArray[int16]{}.GormDBDataType(db, field))
+			assert.Equal(t, test.float64s, NullableOrderedNumberArray[float64]{}.GormDBDataType(db, field))
+			assert.Equal(t, test.float32s, NumberArray[float32]{}.GormDBDataType(db, field))
+		})
+	}
+	assert.Equal(t, "Array(UInt64)", NumberArray[uint64]{}.GormDBDataType(createMockDB("clickhouse"), field))
+
+	type score float64
+	assert.Equal(t, "double precision[]", NumberArray[score]{}.GormDBDataType(createMockDB("postgres"), field))
+	assert.Equal(t, "array", StringArray{}.GormDataType())
+	assert.Equal(t, "array", NumberArray[int]{}.GormDataType())
+}
+
+func TestGormArrayValue(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		dialectName string
+		sql         string
+		strings     any
+		numbers     any
+	}{
+		{"postgres", "CAST(? AS text[])", `{a,"b c"}`, "{1,2}"},
+		{"mysql", "?", `["a","b c"]`, "[1,2]"},
+		{"sqlite", "?", `["a","b c"]`, "[1,2]"},
+		{"clickhouse", "?", []string{"a", "b c"}, []int64{1, 2}},
+		{"ydb", "?", []string{"a", "b c"}, []int64{1, 2}},
+		{"unknown_dialect", "?", `{a,"b c"}`, "{1,2}"},
+	}
+	for _, test := range tests {
+		t.Run("dialect_"+test.dialectName, func(t *testing.T) {
+			db := createMockDB(test.dialectName)
+			expr := StringArray{"a", "b c"}.GormValue(ctx, db)
+			assert.Equal(t, test.sql, expr.SQL)
+			assert.Equal(t, []any{test.strings}, expr.Vars)
+
+			expr = NumberArray[int64]{1, 2}.GormValue(ctx, db)
+			assert.Equal(t, []any{test.numbers}, expr.Vars)
+
+			assert.Equal(t, "NULL", NullableStringArray(nil).GormValue(ctx, db).SQL)
+			assert.Equal(t, "NULL", NullableNumberArray[int](nil).GormValue(ctx, db).SQL)
+			assert.NotEqual(t, "NULL", StringArray(nil).GormValue(ctx, db).SQL)
+		})
+	}
+	expr := NumberArray[int]{1}.GormValue(ctx, createMockDB("postgres"))
+	assert.Equal(t, "CAST(? AS bigint[])", expr.SQL)
+	expr = StringArray(nil).GormValue(ctx, createMockDB("mysql"))
+	assert.Equal(t, []any{"[]"}, expr.Vars)
+}
+
+func TestGormArrayScan(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		var arr StringArray
+		assert.NoError(t, arr.Scan(`{a,"b c"}`))
+		assert.Equal(t, StringArray{"a", "b c"}, arr)
+		assert.NoError(t, arr.Scan([]byte(`["x","y"]`)))
+		assert.Equal(t, StringArray{"x", "y"}, arr)
+		assert.NoError(t, arr.Scan([]string{"z"}))
+		assert.Equal(t, StringArray{"z"}, arr)
+		assert.NoError(t, arr.Scan(`[0:1]={a,b}`))
+		assert.Equal(t, StringArray{"a", "b"}, arr)
+		assert.Error(t, arr.Scan(`["x",`))
+		assert.Equal(t, 0, arr.IndexOf("a"))
+		assert.True(t, arr.OneOf([]string{"b"}))
+
+		var narr NullableStringArray
+		assert.NoError(t, narr.Scan(nil))
+		assert.Nil(t, narr)
+		assert.NoError(t, narr.Scan(`[]`))
+		assert.Equal(t, NullableStringArray{}, narr)
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		var arr NumberArray[int64]
+		assert.NoError(t, arr.Scan(`{3,1}`))
+		assert.Equal(t, NumberArray[int64]{3, 1}, arr)
+		assert.NoError(t, arr.Scan([]int64{5, 4}))
+		assert.Equal(t, NumberArray[int64]{5, 4}, arr)
+		assert.NoError(t, arr.Scan([]byte("[1,2]")))
+		assert.Equal(t, 2, arr.Len())
+
+		var oarr OrderedNumberArray[float64]
+		assert.NoError(t, oarr.Scan(`[2.5,1]`))
+		assert.Equal(t, OrderedNumberArray[float64]{1, 2.5}, oarr.Sort())
+		assert.Equal(t, 1, oarr.IndexOf(2.5))
+
+		var narr NullableOrderedNumberArray[int]
+		assert.NoError(t, narr.Scan(nil))
+		assert.Nil(t, narr)
+
+		data, err := NumberArray[int](nil).MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(data))
+	})
+}
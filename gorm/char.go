@@ -20,15 +20,7 @@ func (Char) GormDataType() string {
 
 // GormDBDataType gorm db data type
 func (c Char) GormDBDataType(db *gorm.DB, field *schema.Field) string {
-	switch db.Dialector.Name() {
-	case "mysql", "mariadb", "postgres", "sqlserver":
-		return "char"
-	case "sqlite", "sqlite3":
-		return "text"
-	case "ydb", "clickhouse":
-		return "Int32"
-	}
-	return ""
+	return charGormDBDataType(db)
 }
 
 // Value implements the driver.Valuer interface, char field
@@ -38,11 +30,7 @@ func (f Char) Value() (driver.Value, error) {
 
 // GormValue gorm expr for char field
 func (f Char) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
-	switch db.Dialector.Name() {
-	case "ydb", "clickhouse":
-		return clause.Expr{SQL: "CAST(? AS Int32)", Vars: []any{int32(f)}}
-	}
-	return clause.Expr{SQL: "?", Vars: []any{f}}
+	return charGormValue(db, rune(f), f)
 }
 
 // Scan implements the sql.Scanner interface, char field
@@ -59,3 +47,23 @@ func (f Char) MarshalJSON() ([]byte, error) {
 func (f *Char) UnmarshalJSON(b []byte) (err error) {
 	return (*gosql.Char)(f).UnmarshalJSON(b)
 }
+
+func charGormDBDataType(db *gorm.DB) string {
+	switch db.Dialector.Name() {
+	case "mysql", "mariadb", "postgres", "sqlserver":
+		return "char"
+	case "sqlite", "sqlite3":
+		return "text"
+	case "ydb", "clickhouse":
+		return "Int32"
+	}
+	return ""
+}
+
+func charGormValue(db *gorm.DB, code rune, value any) clause.Expr {
+	switch db.Dialector.Name() {
+	case "ydb", "clickhouse":
+		return clause.Expr{SQL: "CAST(? AS Int32)", Vars: []any{int32(code)}}
+	}
+	return clause.Expr{SQL: "?", Vars: []any{value}}
+}
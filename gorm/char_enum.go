@@ -0,0 +1,77 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// CharEnum field type declaration with GORM type methods
+type CharEnum[D gosql.CharEnumDefinition] gosql.CharEnum[D]
+
+// GormDataType gorm common data type
+func (CharEnum[D]) GormDataType() string {
+	return "char"
+}
+
+// GormDBDataType gorm db data type
+func (f CharEnum[D]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return charGormDBDataType(db)
+}
+
+// GormValue gorm expr for char field
+func (f CharEnum[D]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if _, err := gosql.CharEnum[D](f).Value(); err != nil {
+		_ = db.AddError(err)
+	}
+	return charGormValue(db, rune(f), f)
+}
+
+// Registry returns the registry of the enum codes
+func (f CharEnum[D]) Registry() *gosql.CharEnumRegistry {
+	return gosql.CharEnum[D](f).Registry()
+}
+
+// CheckConstraint returns SQL expression for the CHECK constraint of the column
+func (f CharEnum[D]) CheckConstraint(column string) string {
+	return f.Registry().CheckConstraint(column)
+}
+
+// Name returns the symbolic name of the code
+func (f CharEnum[D]) Name() string {
+	return gosql.CharEnum[D](f).Name()
+}
+
+// String returns the symbolic name or the code if the name is not defined
+func (f CharEnum[D]) String() string {
+	return gosql.CharEnum[D](f).String()
+}
+
+// Valid returns true if the code is allowed
+func (f CharEnum[D]) Valid() bool {
+	return gosql.CharEnum[D](f).Valid()
+}
+
+// Value implements the driver.Valuer interface, char field
+func (f CharEnum[D]) Value() (driver.Value, error) {
+	return gosql.CharEnum[D](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, char field
+func (f *CharEnum[D]) Scan(value any) error {
+	return (*gosql.CharEnum[D])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f CharEnum[D]) MarshalJSON() ([]byte, error) {
+	return gosql.CharEnum[D](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *CharEnum[D]) UnmarshalJSON(b []byte) error {
+	return (*gosql.CharEnum[D])(f).UnmarshalJSON(b)
+}
@@ -0,0 +1,51 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+var testStatusRegistry = gosql.NewCharEnumRegistry(
+	gosql.CharEnumItem{Code: 'A', Name: "active"},
+	gosql.CharEnumItem{Code: 'P', Name: "paused"},
+)
+
+type testStatusDefinition struct{}
+
+func (testStatusDefinition) CharEnumRegistry() *gosql.CharEnumRegistry { return testStatusRegistry }
+
+func TestGormCharEnum(t *testing.T) {
+	var c CharEnum[testStatusDefinition]
+	field := &schema.Field{Name: "test_field"}
+
+	for _, dialect := range []string{"mysql", "postgres", "sqlite", "ydb", "clickhouse", "unknown"} {
+		t.Run("dialect_"+dialect, func(t *testing.T) {
+			db := createMockDB(dialect)
+			assert.Equal(t, Char(0).GormDBDataType(db, field), c.GormDBDataType(db, field))
+		})
+	}
+
+	t.Run("gorm_value", func(t *testing.T) {
+		db := createMockDB("clickhouse")
+		expr := CharEnum[testStatusDefinition]('A').GormValue(context.Background(), db)
+		assert.Equal(t, "CAST(? AS Int32)", expr.SQL)
+		assert.Equal(t, []any{int32('A')}, expr.Vars)
+		assert.NoError(t, db.Error)
+
+		db = createMockDB("postgres")
+		expr = CharEnum[testStatusDefinition]('X').GormValue(context.Background(), db)
+		assert.Equal(t, "?", expr.SQL)
+		assert.ErrorIs(t, db.Error, gosql.ErrInvalidEnumValue)
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		assert.NoError(t, c.Scan("P"))
+		assert.Equal(t, "paused", c.Name())
+		assert.ErrorIs(t, c.Scan("Z"), gosql.ErrInvalidEnumValue)
+		assert.Equal(t, "test_field IN ('A','P')", c.CheckConstraint("test_field"))
+	})
+}
@@ -0,0 +1,42 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// CharState field type declaration with GORM type methods
+//
+// The transition from the state loaded from the database to the current
+// state is validated when the value is written, so the illegal transition
+// is rejected before the query is executed.
+type CharState[D gosql.CharStateDefinition] struct {
+	gosql.CharState[D]
+}
+
+// NewCharState creates the state with initial code
+func NewCharState[D gosql.CharStateDefinition](code gosql.Char) CharState[D] {
+	return CharState[D]{gosql.NewCharState[D](code)}
+}
+
+// GormDataType gorm common data type
+func (CharState[D]) GormDataType() string {
+	return "char"
+}
+
+// GormDBDataType gorm db data type
+func (s CharState[D]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return charGormDBDataType(db)
+}
+
+// GormValue gorm expr for char field
+func (s CharState[D]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if err := s.Validate(); err != nil {
+		_ = db.AddError(err)
+	}
+	return charGormValue(db, rune(s.Code()), s.CharState)
+}
@@ -0,0 +1,67 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testOrderStates = gosql.NewCharStateMachine(gosql.NewCharEnumRegistry(
+	gosql.CharEnumItem{Code: 'D', Name: "draft"},
+	gosql.CharEnumItem{Code: 'A', Name: "active"},
+	gosql.CharEnumItem{Code: 'R', Name: "archived"},
+)).Allow('D', 'A').Allow('A', 'R')
+
+type testOrderStateDefinition struct{}
+
+func (testOrderStateDefinition) CharStateMachine() *gosql.CharStateMachine { return testOrderStates }
+
+func TestGormCharState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("allowed", func(t *testing.T) {
+		var s CharState[testOrderStateDefinition]
+		assert.NoError(t, s.Scan("A"))
+		assert.NoError(t, s.Transition('R'))
+
+		db := createMockDB("postgres")
+		expr := s.GormValue(ctx, db)
+		assert.NoError(t, db.Error)
+		assert.Equal(t, "?", expr.SQL)
+
+		db = createMockDB("clickhouse")
+		expr = s.GormValue(ctx, db)
+		assert.Equal(t, []any{int32('R')}, expr.Vars)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		var s CharState[testOrderStateDefinition]
+		assert.NoError(t, s.Scan("D"))
+		assert.NoError(t, s.Transition('A'))
+		assert.NoError(t, s.Transition('R'))
+
+		db := createMockDB("postgres")
+		s.GormValue(ctx, db)
+		assert.NoError(t, db.Error)
+	})
+
+	t.Run("illegal", func(t *testing.T) {
+		var s CharState[testOrderStateDefinition]
+		assert.NoError(t, s.Scan("R"))
+		assert.NoError(t, s.UnmarshalJSON([]byte(`"draft"`)))
+
+		db := createMockDB("mysql")
+		s.GormValue(ctx, db)
+		assert.ErrorIs(t, db.Error, gosql.ErrInvalidTransition)
+	})
+
+	t.Run("new", func(t *testing.T) {
+		s := NewCharState[testOrderStateDefinition]('A')
+		db := createMockDB("sqlite")
+		s.GormValue(ctx, db)
+		assert.NoError(t, db.Error)
+		assert.Equal(t, "text", s.GormDBDataType(db, nil))
+	})
+}
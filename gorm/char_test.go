@@ -144,14 +144,16 @@ func TestGormCharJSON(t *testing.T) {
 
 	t.Run("unmarshal:direct", func(t *testing.T) {
 		// Test direct UnmarshalJSON calls
-		// Note: The implementation delegates to gosql.Char which uses decodeChar directly
 		tests := []struct {
 			json     string
 			expected Char
 		}{
-			{`"A"`, Char('"')}, // Gets the first character (quote)
-			{`"5"`, Char('"')}, // Gets the first character (quote)
-			{`" "`, Char('"')}, // Gets the first character (quote)
+			{`"A"`, Char('A')},
+			{`"5"`, Char('5')},
+			{`" "`, Char(' ')},
+			{`"Ё"`, Char('Ё')},
+			{`"\u2713"`, Char('✓')},
+			{`65`, Char('A')},
 		}
 
 		for _, test := range tests {
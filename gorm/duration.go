@@ -0,0 +1,149 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Duration field type declaration with GORM type methods
+type Duration gosql.Duration
+
+// GormDataType gorm common data type
+func (Duration) GormDataType() string {
+	return "interval"
+}
+
+// GormDBDataType gorm db data type
+func (d Duration) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return durationGormDBDataType(db, false)
+}
+
+// Duration returns the gosql.Duration value
+func (d Duration) Duration() gosql.Duration {
+	return gosql.Duration(d)
+}
+
+// String implements the Stringer interface
+func (d Duration) String() string {
+	return gosql.Duration(d).String()
+}
+
+// Value implements the driver.Valuer interface, the nanoseconds of the bigint column.
+// The postgres `interval` and YDB `Interval` columns are written by GormValue.
+func (d Duration) Value() (driver.Value, error) {
+	return gosql.Duration(d).Nanoseconds(), nil
+}
+
+// GormValue gorm expr for duration field
+func (d Duration) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return durationGormValue(db, gosql.Duration(d))
+}
+
+// Scan implements the sql.Scanner interface, duration field.
+// Numbers and numeric strings are the nanoseconds of the bigint column,
+// other strings are durations or postgres intervals.
+func (d *Duration) Scan(value any) error {
+	var v gosql.DurationNanoseconds
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return gosql.Duration(d).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	return (*gosql.Duration)(d).UnmarshalJSON(b)
+}
+
+// NullableDuration field type declaration with GORM type methods
+type NullableDuration struct {
+	gosql.NullableDuration
+}
+
+// NewNullableDuration returns the valid duration
+func NewNullableDuration(d gosql.Duration) NullableDuration {
+	return NullableDuration{gosql.NewNullableDuration(d)}
+}
+
+// GormDataType gorm common data type
+func (NullableDuration) GormDataType() string {
+	return "interval"
+}
+
+// GormDBDataType gorm db data type
+func (d NullableDuration) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return durationGormDBDataType(db, true)
+}
+
+// Value implements the driver.Valuer interface, the nanoseconds of the bigint column or NULL.
+// The postgres `interval` and YDB `Interval` columns are written by GormValue.
+func (d NullableDuration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Duration.Nanoseconds(), nil
+}
+
+// Scan implements the sql.Scanner interface like Duration.Scan and accepts NULL
+func (d *NullableDuration) Scan(value any) error {
+	if value == nil {
+		d.NullableDuration = gosql.NullableDuration{}
+		return nil
+	}
+	var v Duration
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	d.NullableDuration = gosql.NewNullableDuration(v.Duration())
+	return nil
+}
+
+// GormValue gorm expr for duration field
+func (d NullableDuration) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if !d.Valid {
+		return clause.Expr{SQL: "NULL"}
+	}
+	return durationGormValue(db, d.Duration)
+}
+
+func durationGormDBDataType(db *gorm.DB, nullable bool) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "interval"
+	case "mysql", "mariadb", "sqlite", "sqlite3", "sqlserver":
+		return "bigint"
+	case "ydb":
+		return "Interval"
+	case "clickhouse":
+		if nullable {
+			return "Nullable(Int64)"
+		}
+		return "Int64"
+	}
+	return ""
+}
+
+// durationGormValue returns the value in the representation of the column type:
+// interval text on postgres, microseconds on YDB and nanoseconds on others
+func durationGormValue(db *gorm.DB, d gosql.Duration) clause.Expr {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return clause.Expr{SQL: "CAST(? AS interval)", Vars: []any{gosql.PostgresIntervalFormat.Format(d)}}
+	case "ydb":
+		return clause.Expr{SQL: "CAST(? AS Interval)", Vars: []any{d.Microseconds()}}
+	case "clickhouse":
+		return clause.Expr{SQL: "CAST(? AS Int64)", Vars: []any{d.Nanoseconds()}}
+	}
+	return clause.Expr{SQL: "?", Vars: []any{d.Nanoseconds()}}
+}
@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+func TestGormDuration(t *testing.T) {
+	d := Duration(gosql.Day + 2*gosql.Hour)
+	field := &schema.Field{Name: "test_field"}
+
+	tests := []struct {
+		dialectName  string
+		expectedType string
+		nullableType string
+		expectedSQL  string
+		expectedVar  any
+	}{
+		{"postgres", "interval", "interval", "CAST(? AS interval)", "1 day 02:00:00"},
+		{"mysql", "bigint", "bigint", "?", int64(d)},
+		{"sqlite", "bigint", "bigint", "?", int64(d)},
+		{"ydb", "Interval", "Interval", "CAST(? AS Interval)", int64(d) / 1000},
+		{"clickhouse", "Int64", "Nullable(Int64)", "CAST(? AS Int64)", int64(d)},
+		{"unknown_dialect", "", "", "?", int64(d)},
+	}
+	for _, test := range tests {
+		t.Run("dialect_"+test.dialectName, func(t *testing.T) {
+			db := createMockDB(test.dialectName)
+			assert.Equal(t, test.expectedType, d.GormDBDataType(db, field))
+			assert.Equal(t, test.nullableType, NullableDuration{}.GormDBDataType(db, field))
+
+			expr := d.GormValue(context.Background(), db)
+			assert.Equal(t, test.expectedSQL, expr.SQL)
+			assert.Equal(t, []any{test.expectedVar}, expr.Vars)
+
+			expr = NewNullableDuration(d.Duration()).GormValue(context.Background(), db)
+			assert.Equal(t, test.expectedSQL, expr.SQL)
+			assert.Equal(t, "NULL", NullableDuration{}.GormValue(context.Background(), db).SQL)
+		})
+	}
+
+	t.Run("scan", func(t *testing.T) {
+		var v Duration
+		assert.Equal(t, "interval", v.GormDataType())
+		assert.NoError(t, v.Scan("1 day 02:00:00"))
+		assert.Equal(t, d, v)
+		assert.NoError(t, v.Scan(int64(gosql.Hour)))
+		assert.Equal(t, gosql.Hour, v.Duration())
+
+		var nv NullableDuration
+		assert.NoError(t, nv.Scan(nil))
+		assert.False(t, nv.Valid)
+		assert.NoError(t, nv.Scan(int64(gosql.Hour)))
+		assert.Equal(t, NewNullableDuration(gosql.Hour), nv)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := d.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(d), v)
+
+		v, err = NewNullableDuration(d.Duration()).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(d), v)
+		v, err = NullableDuration{}.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+
+		// the bigint column returned as text by the driver
+		for _, text := range []any{"93600000000000", []byte("93600000000000")} {
+			var rv Duration
+			assert.NoError(t, rv.Scan(text))
+			assert.Equal(t, d, rv)
+
+			var nv NullableDuration
+			assert.NoError(t, nv.Scan(text))
+			assert.Equal(t, NewNullableDuration(d.Duration()), nv)
+		}
+
+		var rows []map[string]any
+		tx := createDryRunDB("mysql").Table("jobs").Where("timeout > ?", d).Find(&rows)
+		assert.NoError(t, tx.Error)
+		assert.Equal(t, []any{int64(d)}, tx.Statement.Vars)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := d.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `"26h0m0s"`, string(data))
+
+		var v Duration
+		assert.NoError(t, v.UnmarshalJSON([]byte(`"1d"`)))
+		assert.Equal(t, Duration(gosql.Day), v)
+
+		var nv NullableDuration
+		assert.NoError(t, nv.UnmarshalJSON([]byte(`null`)))
+		data, err = nv.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+	})
+}
@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// FixedString field type declaration with GORM type methods
+type FixedString[S gosql.FixedStringSize] gosql.FixedString[S]
+
+// GormDataType gorm common data type
+func (FixedString[S]) GormDataType() string {
+	return "char"
+}
+
+// GormDBDataType gorm db data type
+func (f FixedString[S]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	size := strconv.Itoa(gosql.FixedString[S](f).Size())
+	switch db.Dialector.Name() {
+	case "mysql", "mariadb", "postgres", "sqlserver":
+		return "char(" + size + ")"
+	case "sqlite", "sqlite3":
+		return "text"
+	case "ydb":
+		return "Utf8"
+	case "clickhouse":
+		return "FixedString(" + size + ")"
+	}
+	return ""
+}
+
+// String value without padding
+func (f FixedString[S]) String() string {
+	return string(f)
+}
+
+// Value implements the driver.Valuer interface, fixed string field
+func (f FixedString[S]) Value() (driver.Value, error) {
+	return (gosql.FixedString[S])(f).Value()
+}
+
+// GormValue gorm expr for fixed string field,
+// ClickHouse `FixedString(N)` is validated and padded with zero bytes in bytes
+func (f FixedString[S]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	var (
+		v   any
+		err error
+	)
+	if db.Dialector.Name() == "clickhouse" {
+		v, err = gosql.FixedString[S](f).BytesValue()
+	} else {
+		v, err = f.Value()
+	}
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return clause.Expr{SQL: "?", Vars: []any{v}}
+}
+
+// Scan implements the sql.Scanner interface, fixed string field
+func (f *FixedString[S]) Scan(value any) error {
+	return (*gosql.FixedString[S])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f FixedString[S]) MarshalJSON() ([]byte, error) {
+	return (gosql.FixedString[S])(f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *FixedString[S]) UnmarshalJSON(b []byte) error {
+	return (*gosql.FixedString[S])(f).UnmarshalJSON(b)
+}
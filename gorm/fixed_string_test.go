@@ -0,0 +1,59 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+func TestGormFixedString(t *testing.T) {
+	var f FixedString[gosql.FixedSize3]
+	field := &schema.Field{Name: "test_field"}
+
+	tests := []struct {
+		dialectName  string
+		expectedType string
+	}{
+		{"mysql", "char(3)"},
+		{"postgres", "char(3)"},
+		{"sqlite", "text"},
+		{"ydb", "Utf8"},
+		{"clickhouse", "FixedString(3)"},
+		{"unknown_dialect", ""},
+	}
+	for _, test := range tests {
+		t.Run("dialect_"+test.dialectName, func(t *testing.T) {
+			assert.Equal(t, test.expectedType, f.GormDBDataType(createMockDB(test.dialectName), field))
+		})
+	}
+
+	assert.Equal(t, "char", f.GormDataType())
+	if assert.NoError(t, f.Scan("EU ")) {
+		assert.Equal(t, "EU", f.String())
+	}
+	v, err := f.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "EU ", v)
+
+	data, err := f.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `"EU"`, string(data))
+	assert.ErrorIs(t, f.UnmarshalJSON([]byte(`"USDT"`)), gosql.ErrValueTooLong)
+
+	ctx := context.Background()
+	db := createMockDB("postgres")
+	assert.Equal(t, []any{"EU "}, f.GormValue(ctx, db).Vars)
+	assert.NoError(t, db.Error)
+
+	db = createMockDB("clickhouse")
+	assert.Equal(t, []any{"EU\x00"}, f.GormValue(ctx, db).Vars)
+	assert.NoError(t, db.Error)
+
+	f = "ёж"
+	db = createMockDB("clickhouse")
+	f.GormValue(ctx, db)
+	assert.ErrorIs(t, db.Error, gosql.ErrValueTooLong, "FixedString(3) is 3 bytes")
+}
@@ -3,7 +3,7 @@ module github.com/geniusrabbit/gosql/gorm
 go 1.20
 
 require (
-	github.com/geniusrabbit/gosql/v2 v2.3.1
+	github.com/geniusrabbit/gosql/v2 v2.4.0
 	github.com/stretchr/testify v1.9.0
 	gorm.io/gorm v1.31.0
 )
@@ -17,3 +17,7 @@ require (
 	golang.org/x/text v0.20.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// The local copy is used for development, the release requires the tagged v2.4.0
+// of the root module with the new types, see "Releasing" in README.md
+replace github.com/geniusrabbit/gosql/v2 => ../
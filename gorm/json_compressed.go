@@ -0,0 +1,41 @@
+package gorm
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// CompressedJSON field type declaration with GORM type methods,
+// the document is stored in the binary column
+type CompressedJSON[T any] struct {
+	gosql.CompressedJSON[T]
+}
+
+// GormDataType gorm common data type
+func (CompressedJSON[T]) GormDataType() string {
+	return string(schema.Bytes)
+}
+
+// GormDBDataType gorm db data type
+func (j CompressedJSON[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "mysql", "mariadb":
+		return "longblob"
+	case "postgres":
+		return "bytea"
+	case "sqlite", "sqlite3":
+		return "blob"
+	case "sqlserver":
+		return "varbinary(max)"
+	case "ydb", "clickhouse":
+		return "String"
+	}
+	return ""
+}
+
+// Data returns the underlying data
+func (j *CompressedJSON[T]) Data() T {
+	return j.CompressedJSON.Data
+}
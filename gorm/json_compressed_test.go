@@ -0,0 +1,25 @@
+package gorm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormCompressedJSON(t *testing.T) {
+	var js CompressedJSON[map[string]string]
+	assert.Equal(t, "bytes", js.GormDataType())
+	assert.Equal(t, "bytea", js.GormDBDataType(createMockDB("postgres"), nil))
+	assert.Equal(t, "longblob", js.GormDBDataType(createMockDB("mysql"), nil))
+	assert.Equal(t, "blob", js.GormDBDataType(createMockDB("sqlite"), nil))
+	assert.Equal(t, "String", js.GormDBDataType(createMockDB("clickhouse"), nil))
+
+	js.CompressedJSON.Data = map[string]string{"text": strings.Repeat("a", 1000)}
+	v, err := js.Value()
+	assert.NoError(t, err)
+
+	var res CompressedJSON[map[string]string]
+	assert.NoError(t, res.Scan(v))
+	assert.Equal(t, js.Data(), res.Data())
+}
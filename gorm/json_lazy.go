@@ -0,0 +1,29 @@
+package gorm
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// LazyJSON field type declaration with GORM type methods,
+// the document is decoded on the first Get
+type LazyJSON[T any] struct {
+	gosql.LazyJSON[T]
+}
+
+// NewLazyJSON creates new LazyJSON object with decoded data
+func NewLazyJSON[T any](data T) LazyJSON[T] {
+	return LazyJSON[T]{gosql.NewLazyJSON(data)}
+}
+
+// GormDataType gorm common data type
+func (LazyJSON[T]) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType gorm db data type
+func (j LazyJSON[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonGormDBDataType(db, false)
+}
@@ -0,0 +1,27 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormLazyJSON(t *testing.T) {
+	var js LazyJSON[map[string]int]
+	assert.NoError(t, js.Scan([]byte(`{"a": 1}`)))
+	assert.Equal(t, "jsonb", js.GormDBDataType(createMockDB("postgres"), nil))
+	assert.Equal(t, "json", js.GormDataType())
+
+	v, err := js.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a": 1}`, v)
+
+	data, err := js.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, data)
+
+	js = NewLazyJSON(map[string]int{"b": 2})
+	v, err = js.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":2}`, v)
+}
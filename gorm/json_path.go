@@ -0,0 +1,119 @@
+package gorm
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// JSONExtractExpr is the SQL expression which extracts the value of the JSON column by the path,
+// numeric JSON Pointer tokens are used as array indexes
+//
+//	db.Where("? = ?", gorm.JSONExtract("settings", "$.limits.max").AsText(), "10")
+type JSONExtractExpr struct {
+	Column string
+	Path   gosql.JSONPath
+	Text   bool // extract scalar values as text instead of JSON
+	err    error
+}
+
+// JSONExtract returns the expression which extracts the JSON value of the column by the path
+// in JSONPath like `$.a.b[2]` or JSON Pointer like `/a/b/2` syntax
+func JSONExtract(column, path string) JSONExtractExpr {
+	p, err := gosql.ParseJSONPath(path)
+	return JSONExtractExpr{Column: column, Path: p, err: err}
+}
+
+// AsText returns the expression which extracts scalar values as text
+func (e JSONExtractExpr) AsText() JSONExtractExpr {
+	e.Text = true
+	return e
+}
+
+// Build implements clause.Expression
+func (e JSONExtractExpr) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+	if e.err != nil {
+		_ = stmt.AddError(e.err)
+		return
+	}
+	column := clause.Column{Name: e.Column}
+	var expr clause.Expr
+	switch stmt.Dialector.Name() {
+	case "postgres":
+		op := "#>"
+		if e.Text {
+			op = "#>>"
+		}
+		path, _ := gosql.NullableStringArray(jsonPathTokens(e.Path)).Value()
+		expr = clause.Expr{SQL: "(? " + op + " CAST(? AS text[]))", Vars: []any{column, path}}
+	case "mysql", "mariadb":
+		expr = clause.Expr{SQL: "JSON_EXTRACT(?, ?)", Vars: []any{column, jsonSQLPathString(e.Path)}}
+		if e.Text {
+			expr.SQL = "JSON_UNQUOTE(" + expr.SQL + ")"
+		}
+	case "sqlite", "sqlite3":
+		expr = clause.Expr{SQL: "json_extract(?, ?)", Vars: []any{column, jsonSQLPathString(e.Path)}}
+		if !e.Text {
+			expr.SQL = "json_quote(" + expr.SQL + ")"
+		}
+	case "sqlserver":
+		expr = clause.Expr{SQL: "JSON_QUERY(?, ?)", Vars: []any{column, jsonSQLPathString(e.Path)}}
+		if e.Text {
+			expr.SQL = "JSON_VALUE(?, ?)"
+		}
+	case "clickhouse":
+		fn := "JSONExtractRaw"
+		if e.Text {
+			fn = "JSONExtractString"
+		}
+		expr = clause.Expr{SQL: fn + "(?", Vars: []any{column}}
+		for _, seg := range e.Path {
+			expr.SQL += ", ?"
+			if seg.Index >= 0 {
+				expr.Vars = append(expr.Vars, seg.Index+1) // ClickHouse indexes are 1-based
+			} else {
+				expr.Vars = append(expr.Vars, seg.Key)
+			}
+		}
+		expr.SQL += ")"
+	default:
+		_ = stmt.AddError(gorm.ErrUnsupportedDriver)
+		return
+	}
+	expr.Build(builder)
+}
+
+// jsonPathTokens returns keys and indexes of the path for the postgres `text[]` path
+func jsonPathTokens(path gosql.JSONPath) []string {
+	tokens := make([]string, 0, len(path))
+	for _, seg := range path {
+		if seg.Index >= 0 {
+			tokens = append(tokens, strconv.Itoa(seg.Index))
+		} else {
+			tokens = append(tokens, seg.Key)
+		}
+	}
+	return tokens
+}
+
+// jsonSQLPathString returns the MySQL/SQLite/SQL Server path like `$."items"[1]."name"`
+func jsonSQLPathString(path gosql.JSONPath) string {
+	var buf strings.Builder
+	buf.WriteByte('$')
+	for _, seg := range path {
+		if seg.Index >= 0 {
+			buf.WriteString("[" + strconv.Itoa(seg.Index) + "]")
+		} else {
+			buf.WriteString(`."` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(seg.Key) + `"`)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,59 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+func buildJSONExtract(dialect string, expr JSONExtractExpr) (string, []any, error) {
+	db := createDryRunDB(dialect)
+	stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+	expr.Build(stmt)
+	return stmt.SQL.String(), stmt.Vars, db.Error
+}
+
+func TestJSONExtract(t *testing.T) {
+	tests := []struct {
+		dialect string
+		expr    JSONExtractExpr
+		sql     string
+		vars    []any
+	}{
+		{"postgres", JSONExtract("settings", "$.a['b c'][2]"), `("settings" #> CAST(? AS text[]))`, []any{`{a,"b c",2}`}},
+		{"postgres", JSONExtract("settings", "/a/0").AsText(), `("settings" #>> CAST(? AS text[]))`, []any{"{a,0}"}},
+		{"mysql", JSONExtract("settings", "a.b[2]"), `JSON_EXTRACT("settings", ?)`, []any{`$."a"."b"[2]`}},
+		{"mariadb", JSONExtract("settings", "a.b").AsText(), `JSON_UNQUOTE(JSON_EXTRACT("settings", ?))`, []any{`$."a"."b"`}},
+		{"sqlite", JSONExtract("settings", `$['x"y']`), `json_quote(json_extract("settings", ?))`, []any{`$."x\"y"`}},
+		{"sqlite", JSONExtract("settings", "a").AsText(), `json_extract("settings", ?)`, []any{`$."a"`}},
+		{"sqlserver", JSONExtract("settings", "a[0]"), `JSON_QUERY("settings", ?)`, []any{`$."a"[0]`}},
+		{"sqlserver", JSONExtract("settings", "a[0]").AsText(), `JSON_VALUE("settings", ?)`, []any{`$."a"[0]`}},
+		{"clickhouse", JSONExtract("settings", "a[0].b"), `JSONExtractRaw("settings", ?, ?, ?)`, []any{"a", 1, "b"}},
+		{"clickhouse", JSONExtract("settings", "a").AsText(), `JSONExtractString("settings", ?)`, []any{"a"}},
+	}
+	for _, test := range tests {
+		sql, vars, err := buildJSONExtract(test.dialect, test.expr)
+		assert.NoError(t, err, test.sql)
+		assert.Equal(t, test.sql, sql)
+		assert.Equal(t, test.vars, vars, test.sql)
+	}
+
+	_, _, err := buildJSONExtract("postgres", JSONExtract("settings", "a[*]"))
+	assert.ErrorIs(t, err, gosql.ErrInvalidJSONPath)
+	_, _, err = buildJSONExtract("ydb", JSONExtract("settings", "a"))
+	assert.ErrorIs(t, err, gorm.ErrUnsupportedDriver)
+}
+
+func TestJSONExtractQuery(t *testing.T) {
+	var models []trackedSettingsModel
+	tx := createDryRunDB("postgres").
+		Where("? = ?", JSONExtract("settings", "$.limits.max").AsText(), "10").
+		Find(&models)
+	assert.NoError(t, tx.Error)
+	assert.Equal(t, `SELECT * FROM "tracked_settings_models" WHERE ("settings" #>> CAST(? AS text[])) = ?`, tx.Statement.SQL.String())
+	assert.Equal(t, []any{"{limits,max}", "10"}, tx.Statement.Vars)
+}
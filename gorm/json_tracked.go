@@ -0,0 +1,307 @@
+package gorm
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// TrackedJSON field type declaration with GORM type methods,
+// see JSONPartialUpdates for the partial column updates
+type TrackedJSON[T any] struct {
+	gosql.TrackedJSON[T]
+}
+
+// GormDataType gorm common data type
+func (TrackedJSON[T]) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType gorm db data type
+func (j TrackedJSON[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonGormDBDataType(db, false)
+}
+
+// Data returns the underlying data
+func (j *TrackedJSON[T]) Data() T {
+	return j.TrackedJSON.Data
+}
+
+// NullableTrackedJSON field type declaration with GORM type methods,
+// see JSONPartialUpdates for the partial column updates
+type NullableTrackedJSON[T any] struct {
+	gosql.NullableTrackedJSON[T]
+}
+
+// GormDataType gorm common data type
+func (NullableTrackedJSON[T]) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType gorm db data type
+func (j NullableTrackedJSON[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonGormDBDataType(db, true)
+}
+
+// Data returns the underlying data pointer
+func (j *NullableTrackedJSON[T]) Data() *T {
+	return j.NullableTrackedJSON.Data
+}
+
+// trackedJSONValue is implemented by JSON types which remember the scanned document
+type trackedJSONValue interface {
+	Original() json.RawMessage
+	Patch() (gosql.JSONPatch, error)
+}
+
+// trackedJSONResetter is implemented by pointers of tracked JSON types
+type trackedJSONResetter interface {
+	ResetChanges() error
+}
+
+const (
+	jsonPartialUpdatesSetKey    = "gosql:json_partial_updates_set"
+	jsonPartialUpdatesSkipKey   = "gosql:json_partial_updates_skip"
+	jsonPartialUpdatesFieldsKey = "gosql:json_partial_updates_fields"
+)
+
+// JSONPartialUpdates is the GORM plugin which updates changed TrackedJSON columns
+// by paths instead of rewriting the full document and skips unchanged columns:
+// `jsonb_set` and `#-` on postgres, `JSON_SET` and `JSON_REMOVE` on MySQL/MariaDB,
+// `json_set` and `json_remove` on SQLite. Other dialects write the full document.
+// The update without changed columns is not executed and the scanned documents
+// of saved columns are reset after the successful update.
+//
+//	db.Use(gorm.JSONPartialUpdates{})
+type JSONPartialUpdates struct{}
+
+// Name implements gorm.Plugin
+func (JSONPartialUpdates) Name() string {
+	return "gosql:json_partial_updates"
+}
+
+// Initialize implements gorm.Plugin
+func (p JSONPartialUpdates) Initialize(db *gorm.DB) error {
+	if update := db.Callback().Update().Get("gorm:update"); update != nil {
+		err := db.Callback().Update().Replace("gorm:update", func(db *gorm.DB) {
+			if _, skip := db.InstanceGet(jsonPartialUpdatesSkipKey); !skip {
+				update(db)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register(p.Name(), jsonPartialUpdatesBefore); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:update").Register(p.Name()+"_cleanup", jsonPartialUpdatesAfter)
+}
+
+// jsonPartialUpdatesBefore builds the SET clause with partial updates of tracked JSON columns
+func jsonPartialUpdatesBefore(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SQL.Len() > 0 {
+		return
+	}
+	if _, ok := stmt.Clauses["SET"]; ok {
+		return
+	}
+	set := callbacks.ConvertToAssignments(stmt)
+	if len(set) == 0 {
+		return
+	}
+	var (
+		assignments = make(clause.Set, 0, len(set))
+		fields      []*schema.Field
+	)
+	for _, assignment := range set {
+		if val, ok := assignment.Value.(trackedJSONValue); ok {
+			value, changed := jsonPartialUpdate(db, assignment.Column, val)
+			if !changed {
+				continue
+			}
+			assignment.Value = value
+			if field := stmt.Schema.LookUpField(assignment.Column.Name); field != nil {
+				fields = append(fields, field)
+			}
+		}
+		assignments = append(assignments, assignment)
+	}
+	if len(assignments) == 0 {
+		db.InstanceSet(jsonPartialUpdatesSkipKey, true)
+		return
+	}
+	stmt.AddClause(assignments)
+	db.InstanceSet(jsonPartialUpdatesSetKey, true)
+	db.InstanceSet(jsonPartialUpdatesFieldsKey, fields)
+}
+
+// jsonPartialUpdatesAfter removes the SET clause like the default update callback does
+// and marks the saved documents as stored
+func jsonPartialUpdatesAfter(db *gorm.DB) {
+	if _, ok := db.InstanceGet(jsonPartialUpdatesSetKey); !ok {
+		return
+	}
+	delete(db.Statement.Clauses, "SET")
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	fields, _ := db.InstanceGet(jsonPartialUpdatesFieldsKey)
+	for _, field := range fields.([]*schema.Field) {
+		resetTrackedJSON(db, field, db.Statement.ReflectValue)
+	}
+}
+
+// resetTrackedJSON resets the scanned document of the field of the model or models
+func resetTrackedJSON(db *gorm.DB, field *schema.Field, model reflect.Value) {
+	switch model.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < model.Len(); i++ {
+			resetTrackedJSON(db, field, model.Index(i))
+		}
+	case reflect.Pointer:
+		if !model.IsNil() {
+			resetTrackedJSON(db, field, model.Elem())
+		}
+	case reflect.Struct:
+		value := field.ReflectValueOf(db.Statement.Context, model)
+		if !value.CanAddr() {
+			return
+		}
+		if resetter, ok := value.Addr().Interface().(trackedJSONResetter); ok {
+			_ = db.AddError(resetter.ResetChanges())
+		}
+	}
+}
+
+// jsonPartialUpdate returns the value of the column assignment and false if the document is not changed
+func jsonPartialUpdate(db *gorm.DB, column clause.Column, val trackedJSONValue) (any, bool) {
+	original := val.Original()
+	if original == nil {
+		return val, true
+	}
+	patch, err := val.Patch()
+	if err != nil {
+		_ = db.AddError(err)
+		return val, true
+	}
+	if len(patch) == 0 {
+		return nil, false
+	}
+	if bytes.Equal(original, []byte("null")) {
+		return val, true
+	}
+	for _, op := range patch {
+		if op.Path == "" {
+			return val, true
+		}
+	}
+	switch db.Dialector.Name() {
+	case "postgres":
+		return jsonPatchPostgresExpr(column, patch), true
+	case "mysql", "mariadb":
+		if expr, ok := jsonPatchFuncExpr(column, original, patch, "JSON_SET", "JSON_REMOVE", "JSON_EXTRACT(?, '$')"); ok {
+			return expr, true
+		}
+	case "sqlite", "sqlite3":
+		if expr, ok := jsonPatchFuncExpr(column, original, patch, "json_set", "json_remove", "json(?)"); ok {
+			return expr, true
+		}
+	}
+	return val, true
+}
+
+// jsonPatchPostgresExpr returns nested `jsonb_set` and `#-` operations
+func jsonPatchPostgresExpr(column clause.Column, patch gosql.JSONPatch) clause.Expr {
+	expr := clause.Expr{SQL: "?", Vars: []any{column}}
+	for _, op := range patch {
+		tokens, _ := gosql.ParseJSONPointer(op.Path)
+		path, _ := gosql.NullableStringArray(tokens).Value()
+		if op.Op == "remove" {
+			expr = clause.Expr{SQL: "(? #- CAST(? AS text[]))", Vars: []any{expr, path}}
+		} else {
+			expr = clause.Expr{SQL: "jsonb_set(?, CAST(? AS text[]), CAST(? AS jsonb))", Vars: []any{expr, path, string(op.Value)}}
+		}
+	}
+	return expr
+}
+
+// jsonPatchFuncExpr returns nested set and remove function calls with `$.key[0]` paths,
+// consecutive operations of the same kind are merged into one call
+func jsonPatchFuncExpr(column clause.Column, original []byte, patch gosql.JSONPatch, setFunc, removeFunc, valueSQL string) (clause.Expr, bool) {
+	var doc any
+	if err := json.Unmarshal(original, &doc); err != nil {
+		return clause.Expr{}, false
+	}
+	var (
+		expr     = clause.Expr{SQL: "?", Vars: []any{column}}
+		lastFunc string
+		args     strings.Builder
+		vars     []any
+	)
+	flush := func() {
+		if lastFunc != "" {
+			expr = clause.Expr{SQL: lastFunc + "(?" + args.String() + ")", Vars: append([]any{expr}, vars...)}
+		}
+		args.Reset()
+		vars = nil
+	}
+	for _, op := range patch {
+		path, ok := jsonSQLPath(doc, op.Path)
+		if !ok {
+			return clause.Expr{}, false
+		}
+		fn := setFunc
+		if op.Op == "remove" {
+			fn = removeFunc
+		}
+		if fn != lastFunc {
+			flush()
+			lastFunc = fn
+		}
+		args.WriteString(", ?")
+		vars = append(vars, path)
+		if fn == setFunc {
+			args.WriteString(", " + valueSQL)
+			vars = append(vars, string(op.Value))
+		}
+	}
+	flush()
+	return expr, true
+}
+
+// jsonSQLPath converts JSON Pointer to the MySQL/SQLite path like `$."items"[1]."name"`,
+// array indexes are detected by the document structure
+func jsonSQLPath(doc any, pointer string) (string, bool) {
+	path, err := gosql.ParseJSONPath(pointer)
+	if err != nil {
+		return "", false
+	}
+	for i, seg := range path {
+		switch node := doc.(type) {
+		case []any:
+			if seg.Index < 0 {
+				return "", false
+			}
+			doc = nil
+			if seg.Index < len(node) {
+				doc = node[seg.Index]
+			}
+		case map[string]any:
+			path[i].Index = -1
+			doc = node[seg.Key]
+		default:
+			return "", false
+		}
+	}
+	return jsonSQLPathString(path), true
+}
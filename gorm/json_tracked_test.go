@@ -0,0 +1,154 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type trackedSettingsModel struct {
+	ID       int
+	Name     string
+	Settings TrackedJSON[map[string]any]
+	Extra    NullableTrackedJSON[map[string]any]
+}
+
+func newTrackedSettingsModel(t *testing.T) *trackedSettingsModel {
+	m := &trackedSettingsModel{ID: 1, Name: "a"}
+	assert.NoError(t, m.Settings.Scan(`{"a":1,"b":{"c":2,"d":3},"list":[1,2,3],"x.y":true}`))
+	assert.NoError(t, m.Extra.Scan(nil))
+	return m
+}
+
+func dryRunSave(t *testing.T, dialect string, m *trackedSettingsModel) (string, []any) {
+	db := createDryRunDB(dialect)
+	assert.NoError(t, db.Use(JSONPartialUpdates{}))
+	tx := db.Save(m)
+	assert.NoError(t, tx.Error)
+	return tx.Statement.SQL.String(), tx.Statement.Vars
+}
+
+func TestJSONPartialUpdates(t *testing.T) {
+	t.Run("unchanged", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		sql, vars := dryRunSave(t, "postgres", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=? WHERE "id" = ?`, sql)
+		assert.Equal(t, []any{"a", 1}, vars)
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		m.Settings.TrackedJSON.Data["a"] = 2
+		delete(m.Settings.TrackedJSON.Data["b"].(map[string]any), "d")
+		m.Settings.TrackedJSON.Data["x.y"] = false
+		sql, vars := dryRunSave(t, "postgres", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=`+
+			`jsonb_set((jsonb_set("settings", CAST(? AS text[]), CAST(? AS jsonb)) #- CAST(? AS text[])), CAST(? AS text[]), CAST(? AS jsonb))`+
+			` WHERE "id" = ?`, sql)
+		assert.Equal(t, []any{"a", "{a}", "2", "{b,d}", "{x.y}", "false", 1}, vars)
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		m.Settings.TrackedJSON.Data["a"] = "v"
+		m.Settings.TrackedJSON.Data["b"].(map[string]any)["c"] = nil
+		m.Settings.TrackedJSON.Data["list"] = []any{1}
+		sql, vars := dryRunSave(t, "mysql", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=`+
+			`JSON_REMOVE(JSON_SET("settings", ?, JSON_EXTRACT(?, '$'), ?, JSON_EXTRACT(?, '$')), ?, ?)`+
+			` WHERE "id" = ?`, sql)
+		assert.Equal(t, []any{"a", `$."a"`, `"v"`, `$."b"."c"`, "null", `$."list"[2]`, `$."list"[1]`, 1}, vars)
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		m.Settings.TrackedJSON.Data["list"] = []any{1, 2, 3, map[string]any{"k": 1}}
+		sql, vars := dryRunSave(t, "sqlite", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=json_set("settings", ?, json(?)) WHERE "id" = ?`, sql)
+		assert.Equal(t, []any{"a", `$."list"[3]`, `{"k":1}`, 1}, vars)
+	})
+
+	t.Run("full_document", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		m.Settings.TrackedJSON.Data["a"] = 2
+		m.Extra.NullableTrackedJSON.Data = &map[string]any{"k": 1}
+		sql, vars := dryRunSave(t, "sqlserver", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=?,"extra"=? WHERE "id" = ?`, sql)
+		assert.Len(t, vars, 4)
+		assert.Equal(t, m.Extra, vars[2])
+
+		// The not scanned value is written entirely
+		m = &trackedSettingsModel{ID: 1, Name: "a"}
+		m.Settings.TrackedJSON.Data = map[string]any{"a": 1}
+		sql, _ = dryRunSave(t, "postgres", m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=?,"extra"=? WHERE "id" = ?`, sql)
+	})
+
+	t.Run("without_plugin", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		tx := createDryRunDB("postgres").Save(m)
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "name"=?,"settings"=?,"extra"=? WHERE "id" = ?`, tx.Statement.SQL.String())
+	})
+
+	t.Run("updates_map", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		m.Settings.TrackedJSON.Data["a"] = 5
+		db := createDryRunDB("postgres")
+		assert.NoError(t, db.Use(JSONPartialUpdates{}))
+		tx := db.Model(&trackedSettingsModel{ID: 1}).Updates(map[string]any{"settings": m.Settings, "extra": m.Extra})
+		assert.Equal(t, `UPDATE "tracked_settings_models" SET "settings"=jsonb_set("settings", CAST(? AS text[]), CAST(? AS jsonb)) WHERE "id" = ?`, tx.Statement.SQL.String())
+	})
+
+	t.Run("nothing_changed", func(t *testing.T) {
+		m := newTrackedSettingsModel(t)
+		db := createDryRunDB("postgres")
+		assert.NoError(t, db.Use(JSONPartialUpdates{}))
+		tx := db.Model(&trackedSettingsModel{ID: 1}).Updates(map[string]any{"settings": m.Settings})
+		assert.NoError(t, tx.Error)
+		assert.Empty(t, tx.Statement.SQL.String())
+	})
+}
+
+func TestJSONPartialUpdatesExec(t *testing.T) {
+	db, pool := createExecDB("postgres")
+	assert.NoError(t, db.Use(JSONPartialUpdates{}))
+
+	m := newTrackedSettingsModel(t)
+	m.Settings.TrackedJSON.Data["a"] = 2
+	assert.NoError(t, db.Save(m).Error)
+	assert.False(t, m.Settings.Changed(), "the saved document is the stored one")
+	assert.False(t, m.Extra.Changed())
+
+	// The second save doesn't repeat the patch
+	assert.NoError(t, db.Save(m).Error)
+
+	// The update without changes is not executed
+	assert.NoError(t, db.Model(m).Select("settings").Updates(m).Error)
+	assert.NoError(t, db.Model(m).Updates(map[string]any{"settings": m.Settings}).Error)
+
+	m.Settings.TrackedJSON.Data["b"] = nil
+	assert.NoError(t, db.Model(m).Select("settings").Updates(m).Error)
+
+	assert.Equal(t, []string{
+		`UPDATE "tracked_settings_models" SET "name"=?,"settings"=jsonb_set("settings", CAST(? AS text[]), CAST(? AS jsonb)) WHERE "id" = ?`,
+		`UPDATE "tracked_settings_models" SET "name"=? WHERE "id" = ?`,
+		`UPDATE "tracked_settings_models" SET "settings"=jsonb_set("settings", CAST(? AS text[]), CAST(? AS jsonb)) WHERE "id" = ?`,
+	}, pool.queries)
+	assert.Equal(t, []any{"{b}", "null", 1}, pool.vars[2])
+	assert.False(t, m.Settings.Changed())
+}
+
+func TestTrackedJSONDataType(t *testing.T) {
+	var (
+		js  TrackedJSON[map[string]any]
+		njs NullableTrackedJSON[map[string]any]
+		db  = &gorm.DB{Config: &gorm.Config{}}
+	)
+	db.Dialector = &mockDialector{name: "postgres"}
+	assert.Equal(t, "json", js.GormDataType())
+	assert.Equal(t, "jsonb", js.GormDBDataType(db, nil))
+	db.Dialector = &mockDialector{name: "clickhouse"}
+	assert.Equal(t, "Nullable(JSON)", njs.GormDBDataType(db, nil))
+	assert.Nil(t, njs.Data())
+}
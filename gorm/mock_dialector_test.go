@@ -1,7 +1,13 @@
 package gorm
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
@@ -49,3 +55,76 @@ func createMockDB(dialectName string) *gorm.DB {
 	db.Dialector = &mockDialector{name: dialectName}
 	return db
 }
+
+// dryRunDialector builds SQL of default callbacks with `"column"` quotes and `?` bind vars
+type dryRunDialector struct {
+	mockDialector
+}
+
+func (d *dryRunDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (d *dryRunDialector) BindVarTo(writer clause.Writer, _ *gorm.Statement, _ any) {
+	_ = writer.WriteByte('?')
+}
+
+func (d *dryRunDialector) QuoteTo(writer clause.Writer, str string) {
+	_, _ = writer.WriteString(`"` + str + `"`)
+}
+
+// createDryRunDB creates the GORM DB which builds statements without execution
+func createDryRunDB(dialectName string) *gorm.DB {
+	db, err := gorm.Open(&dryRunDialector{mockDialector{name: dialectName}}, &gorm.Config{DryRun: true})
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// recordingConnPool records executed statements, every statement affects one row
+type recordingConnPool struct {
+	queries []string
+	vars    [][]any
+}
+
+func (p *recordingConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (p *recordingConnPool) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	p.queries = append(p.queries, query)
+	p.vars = append(p.vars, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (p *recordingConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errors.New("query is not supported")
+}
+
+func (p *recordingConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+// execDialector executes statements of default callbacks by the recording pool
+type execDialector struct {
+	dryRunDialector
+	pool *recordingConnPool
+}
+
+func (d *execDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.pool
+	return d.dryRunDialector.Initialize(db)
+}
+
+// createExecDB creates the GORM DB which executes statements by the recording pool
+func createExecDB(dialectName string) (*gorm.DB, *recordingConnPool) {
+	pool := &recordingConnPool{}
+	dialector := &execDialector{dryRunDialector: dryRunDialector{mockDialector{name: dialectName}}, pool: pool}
+	db, err := gorm.Open(dialector, &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		panic(err)
+	}
+	return db, pool
+}
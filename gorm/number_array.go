@@ -0,0 +1,267 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// NumberArray field type declaration with GORM type methods
+type NumberArray[T gosql.Number] gosql.NumberArray[T]
+
+// GormDataType gorm common data type
+func (NumberArray[T]) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f NumberArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, numberArrayElementType[T]())
+}
+
+// GormValue gorm expr for array field
+func (f NumberArray[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, numberArrayElementType[T](), []T(f), false, f)
+}
+
+// Sort array
+func (f NumberArray[T]) Sort() NumberArray[T] {
+	gosql.NumberArray[T](f).Sort()
+	return f
+}
+
+// Len of array
+func (f NumberArray[T]) Len() int { return len(f) }
+
+// IndexOf array value
+func (f NumberArray[T]) IndexOf(v T) int {
+	return gosql.NumberArray[T](f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f NumberArray[T]) OneOf(vals []T) bool {
+	return gosql.NumberArray[T](f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []T field
+func (f NumberArray[T]) Value() (driver.Value, error) {
+	return gosql.NumberArray[T](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field.
+// Accepts the PostgreSQL array literal, JSON array or []T
+func (f *NumberArray[T]) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[T](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.NumberArray[T])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NumberArray[T]) MarshalJSON() ([]byte, error) {
+	return gosql.NumberArray[T](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NumberArray[T]) UnmarshalJSON(b []byte) error {
+	return (*gosql.NumberArray[T])(f).UnmarshalJSON(b)
+}
+
+// NullableNumberArray field type declaration with GORM type methods
+type NullableNumberArray[T gosql.Number] gosql.NullableNumberArray[T]
+
+// GormDataType gorm common data type
+func (NullableNumberArray[T]) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f NullableNumberArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, numberArrayElementType[T]())
+}
+
+// GormValue gorm expr for array field
+func (f NullableNumberArray[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, numberArrayElementType[T](), []T(f), true, f)
+}
+
+// Sort array
+func (f NullableNumberArray[T]) Sort() NullableNumberArray[T] {
+	gosql.NullableNumberArray[T](f).Sort()
+	return f
+}
+
+// Len of array
+func (f NullableNumberArray[T]) Len() int { return len(f) }
+
+// IndexOf array value
+func (f NullableNumberArray[T]) IndexOf(v T) int {
+	return gosql.NullableNumberArray[T](f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f NullableNumberArray[T]) OneOf(vals []T) bool {
+	return gosql.NullableNumberArray[T](f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []T field
+func (f NullableNumberArray[T]) Value() (driver.Value, error) {
+	return gosql.NullableNumberArray[T](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field.
+// Accepts the PostgreSQL array literal, JSON array or []T
+func (f *NullableNumberArray[T]) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[T](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.NullableNumberArray[T])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NullableNumberArray[T]) MarshalJSON() ([]byte, error) {
+	return gosql.NullableNumberArray[T](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NullableNumberArray[T]) UnmarshalJSON(b []byte) error {
+	return (*gosql.NullableNumberArray[T])(f).UnmarshalJSON(b)
+}
+
+// OrderedNumberArray field type declaration with GORM type methods
+type OrderedNumberArray[T gosql.Number] gosql.OrderedNumberArray[T]
+
+// GormDataType gorm common data type
+func (OrderedNumberArray[T]) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f OrderedNumberArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, numberArrayElementType[T]())
+}
+
+// GormValue gorm expr for array field
+func (f OrderedNumberArray[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, numberArrayElementType[T](), []T(f), false, f)
+}
+
+// Sort array
+func (f OrderedNumberArray[T]) Sort() OrderedNumberArray[T] {
+	gosql.OrderedNumberArray[T](f).Sort()
+	return f
+}
+
+// Len of array
+func (f OrderedNumberArray[T]) Len() int { return len(f) }
+
+// IndexOf array value
+func (f OrderedNumberArray[T]) IndexOf(v T) int {
+	return gosql.OrderedNumberArray[T](f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f OrderedNumberArray[T]) OneOf(vals []T) bool {
+	return gosql.OrderedNumberArray[T](f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []T field
+func (f OrderedNumberArray[T]) Value() (driver.Value, error) {
+	return gosql.OrderedNumberArray[T](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field.
+// Accepts the PostgreSQL array literal, JSON array or []T
+func (f *OrderedNumberArray[T]) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[T](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.OrderedNumberArray[T])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f OrderedNumberArray[T]) MarshalJSON() ([]byte, error) {
+	return gosql.OrderedNumberArray[T](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *OrderedNumberArray[T]) UnmarshalJSON(b []byte) error {
+	return (*gosql.OrderedNumberArray[T])(f).UnmarshalJSON(b)
+}
+
+// NullableOrderedNumberArray field type declaration with GORM type methods
+type NullableOrderedNumberArray[T gosql.Number] gosql.NullableOrderedNumberArray[T]
+
+// GormDataType gorm common data type
+func (NullableOrderedNumberArray[T]) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f NullableOrderedNumberArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, numberArrayElementType[T]())
+}
+
+// GormValue gorm expr for array field
+func (f NullableOrderedNumberArray[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, numberArrayElementType[T](), []T(f), true, f)
+}
+
+// Sort array
+func (f NullableOrderedNumberArray[T]) Sort() NullableOrderedNumberArray[T] {
+	gosql.NullableOrderedNumberArray[T](f).Sort()
+	return f
+}
+
+// Len of array
+func (f NullableOrderedNumberArray[T]) Len() int { return len(f) }
+
+// IndexOf array value
+func (f NullableOrderedNumberArray[T]) IndexOf(v T) int {
+	return gosql.NullableOrderedNumberArray[T](f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f NullableOrderedNumberArray[T]) OneOf(vals []T) bool {
+	return gosql.NullableOrderedNumberArray[T](f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []T field
+func (f NullableOrderedNumberArray[T]) Value() (driver.Value, error) {
+	return gosql.NullableOrderedNumberArray[T](f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []T field.
+// Accepts the PostgreSQL array literal, JSON array or []T
+func (f *NullableOrderedNumberArray[T]) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[T](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.NullableOrderedNumberArray[T])(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NullableOrderedNumberArray[T]) MarshalJSON() ([]byte, error) {
+	return gosql.NullableOrderedNumberArray[T](f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NullableOrderedNumberArray[T]) UnmarshalJSON(b []byte) error {
+	return (*gosql.NullableOrderedNumberArray[T])(f).UnmarshalJSON(b)
+}
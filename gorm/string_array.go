@@ -0,0 +1,131 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/geniusrabbit/gosql/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// StringArray field type declaration with GORM type methods
+type StringArray gosql.StringArray
+
+// GormDataType gorm common data type
+func (StringArray) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f StringArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, stringArrayElementType)
+}
+
+// GormValue gorm expr for array field
+func (f StringArray) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, stringArrayElementType, []string(f), false, f)
+}
+
+// Len of array
+func (f StringArray) Len() int { return len(f) }
+
+// IndexOf array value
+func (f StringArray) IndexOf(v string) int {
+	return gosql.StringArray(f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f StringArray) OneOf(vals []string) bool {
+	return gosql.StringArray(f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []string field
+func (f StringArray) Value() (driver.Value, error) {
+	return gosql.StringArray(f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []string field.
+// Accepts the PostgreSQL array literal, JSON array or []string
+func (f *StringArray) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[string](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.StringArray)(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f StringArray) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(f))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *StringArray) UnmarshalJSON(b []byte) error {
+	return (*gosql.StringArray)(f).UnmarshalJSON(b)
+}
+
+// NullableStringArray field type declaration with GORM type methods
+type NullableStringArray gosql.NullableStringArray
+
+// GormDataType gorm common data type
+func (NullableStringArray) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType gorm db data type
+func (f NullableStringArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayGormDBDataType(db, stringArrayElementType)
+}
+
+// GormValue gorm expr for array field
+func (f NullableStringArray) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return arrayGormValue(db, stringArrayElementType, []string(f), true, f)
+}
+
+// Len of array
+func (f NullableStringArray) Len() int { return len(f) }
+
+// IndexOf array value
+func (f NullableStringArray) IndexOf(v string) int {
+	return gosql.NullableStringArray(f).IndexOf(v)
+}
+
+// OneOf value in array
+func (f NullableStringArray) OneOf(vals []string) bool {
+	return gosql.NullableStringArray(f).OneOf(vals)
+}
+
+// Value implements the driver.Valuer interface, []string field
+func (f NullableStringArray) Value() (driver.Value, error) {
+	return gosql.NullableStringArray(f).Value()
+}
+
+// Scan implements the sql.Scanner interface, []string field.
+// Accepts the PostgreSQL array literal, JSON array or []string
+func (f *NullableStringArray) Scan(value any) error {
+	if list, ok, err := decodeArrayValue[string](value); ok {
+		if err == nil {
+			*f = list
+		}
+		return err
+	}
+	return (*gosql.NullableStringArray)(f).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler
+func (f NullableStringArray) MarshalJSON() ([]byte, error) {
+	return gosql.NullableStringArray(f).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (f *NullableStringArray) UnmarshalJSON(b []byte) error {
+	return (*gosql.NullableStringArray)(f).UnmarshalJSON(b)
+}
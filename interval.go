@@ -0,0 +1,254 @@
+package gosql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is the calendar period like the PostgreSQL `interval` type.
+// Unlike Duration the months and days are kept separately from the time part
+// so "1 month" is added to the date respecting the month length and DST.
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
+// NewInterval returns the interval from the calendar parts and the time part
+// truncated to microseconds
+func NewInterval(years, months, days int, d Duration) Interval {
+	return Interval{
+		Months:       int32(years*12 + months),
+		Days:         int32(days),
+		Microseconds: d.Microseconds(),
+	}
+}
+
+// IntervalOfDuration returns the interval with the time part only
+func IntervalOfDuration(d Duration) Interval {
+	return Interval{Microseconds: d.Microseconds()}
+}
+
+// ParseIntervalValue parses the PostgreSQL interval in any IntervalStyle
+// (postgres, postgres_verbose, sql_standard, iso_8601) or ISO 8601 duration
+// like "1 year 2 mons 3 days 04:05:06", "@ 1 hour ago", "1-2 3 4:05:06" or "P1Y2M3DT4H"
+// keeping months and days, see ParseInterval for the Duration result
+func ParseIntervalValue(s string) (Interval, error) {
+	parts, err := parseIntervalParts(s)
+	if err != nil {
+		return Interval{}, err
+	}
+	micros := parts.nanos / int64(Microsecond)
+	if rest := parts.nanos % int64(Microsecond); rest >= 500 {
+		micros++
+	} else if rest <= -500 {
+		micros--
+	}
+	if parts.months > math.MaxInt32 || parts.months < math.MinInt32 ||
+		parts.days > math.MaxInt32 || parts.days < math.MinInt32 {
+		return Interval{}, &DurationParseError{Input: s, Pos: 0, Msg: "value overflow"}
+	}
+	return Interval{Months: int32(parts.months), Days: int32(parts.days), Microseconds: micros}, nil
+}
+
+// MustParseIntervalValue parses the interval or panics
+func MustParseIntervalValue(s string) Interval {
+	v, err := ParseIntervalValue(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// IsZero returns true if the interval is empty
+func (v Interval) IsZero() bool {
+	return v.Months == 0 && v.Days == 0 && v.Microseconds == 0
+}
+
+// Neg returns the interval with the opposite sign
+func (v Interval) Neg() Interval {
+	return Interval{Months: -v.Months, Days: -v.Days, Microseconds: -v.Microseconds}
+}
+
+// Duration returns the fixed duration with the month of 30 days
+// and the day of 24 hours like PostgreSQL compares intervals, so 12 months are 360 days.
+// The value out of the Duration range is saturated.
+func (v Interval) Duration() Duration {
+	d, ok := v.parts().duration()
+	if !ok || v.Microseconds > math.MaxInt64/int64(Microsecond) || v.Microseconds < math.MinInt64/int64(Microsecond) {
+		approx := float64(v.Months)*float64(Month) + float64(v.Days)*float64(Day) + float64(v.Microseconds)*float64(Microsecond)
+		if approx < 0 {
+			return Duration(math.MinInt64)
+		}
+		return Duration(math.MaxInt64)
+	}
+	return d
+}
+
+// AddTo returns the time moved by the interval like PostgreSQL does it:
+// months are added first with clamping to the end of the month,
+// then calendar days in the location of the time, then the time part.
+//
+//	2024-01-31 + 1 month = 2024-02-29
+func (v Interval) AddTo(t time.Time) time.Time {
+	if v.Months != 0 {
+		year, month, day := t.Date()
+		hour, min, sec := t.Clock()
+		total := int(month) - 1 + int(v.Months)
+		year += total / 12
+		if total %= 12; total < 0 {
+			total += 12
+			year--
+		}
+		month = time.Month(total + 1)
+		if last := daysInMonth(year, month); day > last {
+			day = last
+		}
+		t = time.Date(year, month, day, hour, min, sec, t.Nanosecond(), t.Location())
+	}
+	if v.Days != 0 {
+		t = t.AddDate(0, 0, int(v.Days))
+	}
+	return t.Add(time.Duration(v.Microseconds) * time.Microsecond)
+}
+
+// String returns the interval in the postgres IntervalStyle like `1 year 2 mons 3 days 04:05:06.5`
+func (v Interval) String() string {
+	var (
+		buff   strings.Builder
+		before bool // previous field is negative
+		field  = func(value int64, singular, plural string) {
+			if value == 0 {
+				return
+			}
+			if buff.Len() > 0 {
+				buff.WriteByte(' ')
+			}
+			if before && value > 0 {
+				buff.WriteByte('+')
+			}
+			buff.WriteString(strconv.FormatInt(value, 10))
+			if value == 1 {
+				buff.WriteString(singular)
+			} else {
+				buff.WriteString(plural)
+			}
+			before = value < 0
+		}
+	)
+	field(int64(v.Months/12), " year", " years")
+	field(int64(v.Months%12), " mon", " mons")
+	field(int64(v.Days), " day", " days")
+	if v.Microseconds != 0 || buff.Len() == 0 {
+		if buff.Len() > 0 {
+			buff.WriteByte(' ')
+		}
+		micros := uint64(v.Microseconds)
+		if v.Microseconds < 0 {
+			buff.WriteByte('-')
+			micros = -micros
+		} else if before {
+			buff.WriteByte('+')
+		}
+		fmt.Fprintf(&buff, "%02d:%02d:%02d", micros/3600e6, micros%3600e6/60e6, micros%60e6/1e6)
+		buff.WriteString(formatDurationFraction(micros % 1e6 * uint64(Microsecond)))
+	}
+	return buff.String()
+}
+
+// ISO8601 returns the interval in ISO 8601 format like `P1Y2M3DT4H5M6.5S`
+func (v Interval) ISO8601() string {
+	if v.IsZero() {
+		return "PT0S"
+	}
+	var buff strings.Builder
+	field := func(value int64, designator byte) {
+		if value != 0 {
+			buff.WriteString(strconv.FormatInt(value, 10))
+			buff.WriteByte(designator)
+		}
+	}
+	buff.WriteByte('P')
+	field(int64(v.Months/12), 'Y')
+	field(int64(v.Months%12), 'M')
+	field(int64(v.Days), 'D')
+	if v.Microseconds != 0 {
+		sign, micros := "", v.Microseconds
+		if micros < 0 {
+			sign, micros = "-", -micros
+		}
+		buff.WriteByte('T')
+		if h := micros / 3600e6; h > 0 {
+			buff.WriteString(sign + strconv.FormatInt(h, 10) + "H")
+		}
+		if m := micros % 3600e6 / 60e6; m > 0 {
+			buff.WriteString(sign + strconv.FormatInt(m, 10) + "M")
+		}
+		if s := micros % 60e6; s > 0 {
+			buff.WriteString(sign + strconv.FormatInt(s/1e6, 10))
+			buff.WriteString(formatDurationFraction(uint64(s%1e6) * uint64(Microsecond)))
+			buff.WriteByte('S')
+		}
+	}
+	return buff.String()
+}
+
+// Value implements the driver.Valuer interface, returns the interval in the postgres IntervalStyle
+func (v Interval) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Interval) Scan(value any) (err error) {
+	switch val := value.(type) {
+	case string:
+		*v, err = ParseIntervalValue(val)
+	case []byte:
+		*v, err = ParseIntervalValue(string(val))
+	case Interval:
+		*v = val
+	case time.Duration:
+		*v = IntervalOfDuration(Duration(val))
+	case Duration:
+		*v = IntervalOfDuration(val)
+	case nil:
+		return ErrNullValueNotAllowed
+	default:
+		return ErrInvalidScanValue
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler, returns the interval in ISO 8601 format
+func (v Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ISO8601())
+}
+
+// UnmarshalJSON implements the json.Unmarshaller, accepts any format of ParseIntervalValue
+func (v *Interval) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return ErrNullValueNotAllowed
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrInvalidDecodeValue
+	}
+	return v.Scan(s)
+}
+
+func (v Interval) parts() intervalParts {
+	return intervalParts{
+		months: int64(v.Months),
+		days:   int64(v.Days),
+		nanos:  v.Microseconds * int64(Microsecond),
+	}
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
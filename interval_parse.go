@@ -0,0 +1,461 @@
+package gosql
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// intervalParts keeps the components of the PostgreSQL interval
+type intervalParts struct {
+	months int64
+	days   int64
+	nanos  int64
+}
+
+// add returns the sum of the parts and false on the overflow
+func (p intervalParts) add(o intervalParts) (intervalParts, bool) {
+	months, ok1 := mulAddInt64(p.months, o.months, 1)
+	days, ok2 := mulAddInt64(p.days, o.days, 1)
+	nanos, ok3 := mulAddInt64(p.nanos, o.nanos, 1)
+	return intervalParts{months: months, days: days, nanos: nanos}, ok1 && ok2 && ok3
+}
+
+func (p intervalParts) neg() intervalParts {
+	return intervalParts{months: -p.months, days: -p.days, nanos: -p.nanos}
+}
+
+// duration converts the interval to the fixed duration with the month of 30 days
+// and the day of 24 hours like PostgreSQL compares intervals, so "1 year" is 360 days
+func (p intervalParts) duration() (Duration, bool) {
+	total, ok := mulAddInt64(p.nanos, p.months, int64(Month))
+	if ok {
+		total, ok = mulAddInt64(total, p.days, int64(Day))
+	}
+	return Duration(total), ok
+}
+
+// mulAddInt64 returns acc + v*unit with the overflow check,
+// math.MinInt64 is rejected as well so the result can be negated
+func mulAddInt64(acc, v, unit int64) (int64, bool) {
+	if v != 0 && (v*unit/unit != v) {
+		return 0, false
+	}
+	res := acc + v*unit
+	if (v*unit > 0 && res < acc) || (v*unit < 0 && res > acc) || res == math.MinInt64 {
+		return 0, false
+	}
+	return res, true
+}
+
+// intervalUnit of the PostgreSQL interval field
+type intervalUnit int
+
+const (
+	intervalUnitNone intervalUnit = iota
+	intervalUnitYear
+	intervalUnitMonth
+	intervalUnitWeek
+	intervalUnitDay
+	intervalUnitHour
+	intervalUnitMinute
+	intervalUnitSecond
+	intervalUnitMillisecond
+	intervalUnitMicrosecond
+)
+
+var intervalUnitNames = map[string]intervalUnit{
+	"y": intervalUnitYear, "yr": intervalUnitYear, "yrs": intervalUnitYear, "year": intervalUnitYear, "years": intervalUnitYear,
+	"mon": intervalUnitMonth, "mons": intervalUnitMonth, "month": intervalUnitMonth, "months": intervalUnitMonth,
+	"w": intervalUnitWeek, "week": intervalUnitWeek, "weeks": intervalUnitWeek,
+	"d": intervalUnitDay, "day": intervalUnitDay, "days": intervalUnitDay,
+	"h": intervalUnitHour, "hr": intervalUnitHour, "hrs": intervalUnitHour, "hour": intervalUnitHour, "hours": intervalUnitHour,
+	"m": intervalUnitMinute, "min": intervalUnitMinute, "mins": intervalUnitMinute, "minute": intervalUnitMinute, "minutes": intervalUnitMinute,
+	"s": intervalUnitSecond, "sec": intervalUnitSecond, "secs": intervalUnitSecond, "second": intervalUnitSecond, "seconds": intervalUnitSecond,
+	"ms": intervalUnitMillisecond, "msec": intervalUnitMillisecond, "msecs": intervalUnitMillisecond,
+	"millisecond": intervalUnitMillisecond, "milliseconds": intervalUnitMillisecond,
+	"us": intervalUnitMicrosecond, "usec": intervalUnitMicrosecond, "usecs": intervalUnitMicrosecond,
+	"microsecond": intervalUnitMicrosecond, "microseconds": intervalUnitMicrosecond,
+}
+
+// intervalNumber is the decimal number split into integer and fraction parts
+type intervalNumber struct {
+	neg      bool
+	signed   bool
+	integer  int64
+	fraction string // digits after the point
+}
+
+// fractionOf returns the fraction part multiplied by the unit
+func (n intervalNumber) fractionOf(unit int64) int64 {
+	if n.fraction == "" {
+		return 0
+	}
+	frac, _ := strconv.ParseFloat("0."+n.fraction, 64)
+	return int64(frac*float64(unit) + 0.5)
+}
+
+// parts converts the number of the units to the interval parts,
+// fractions are spread to the smaller units like PostgreSQL does,
+// false is returned on the overflow
+func (n intervalNumber) parts(unit intervalUnit) (intervalParts, bool) {
+	var (
+		p  intervalParts
+		ok = true
+	)
+	switch unit {
+	case intervalUnitYear:
+		p.months, ok = mulAddInt64(n.fractionOf(12), n.integer, 12)
+	case intervalUnitMonth:
+		days := n.fractionOf(30 * int64(Day))
+		p.months, p.days, p.nanos = n.integer, days/int64(Day), days%int64(Day)
+	case intervalUnitWeek:
+		nanos := n.fractionOf(int64(Week))
+		p.days, ok = mulAddInt64(nanos/int64(Day), n.integer, 7)
+		p.nanos = nanos % int64(Day)
+	case intervalUnitDay:
+		p.days, p.nanos = n.integer, n.fractionOf(int64(Day))
+	case intervalUnitHour:
+		p.nanos, ok = mulAddInt64(n.fractionOf(int64(Hour)), n.integer, int64(Hour))
+	case intervalUnitMinute:
+		p.nanos, ok = mulAddInt64(n.fractionOf(int64(Minute)), n.integer, int64(Minute))
+	case intervalUnitSecond:
+		p.nanos, ok = mulAddInt64(n.fractionOf(int64(Second)), n.integer, int64(Second))
+	case intervalUnitMillisecond:
+		p.nanos, ok = mulAddInt64(n.fractionOf(int64(Millisecond)), n.integer, int64(Millisecond))
+	case intervalUnitMicrosecond:
+		p.nanos, ok = mulAddInt64(n.fractionOf(int64(Microsecond)), n.integer, int64(Microsecond))
+	}
+	if n.neg {
+		return p.neg(), ok
+	}
+	return p, ok
+}
+
+// scanIntervalNumber reads the signed decimal number from the beginning of the string
+func scanIntervalNumber(s string) (n intervalNumber, rest string, ok bool) {
+	pos := 0
+	if pos < len(s) && (s[pos] == '-' || s[pos] == '+') {
+		n.neg, n.signed = s[pos] == '-', true
+		pos++
+	}
+	start := pos
+	for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+		pos++
+	}
+	intDigits := s[start:pos]
+	if pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+		fracStart := pos + 1
+		for pos = fracStart; pos < len(s) && s[pos] >= '0' && s[pos] <= '9'; pos++ {
+		}
+		n.fraction = s[fracStart:pos]
+	}
+	if intDigits == "" && n.fraction == "" {
+		return n, s, false
+	}
+	if intDigits != "" {
+		var err error
+		if n.integer, err = strconv.ParseInt(intDigits, 10, 64); err != nil {
+			return n, s, false
+		}
+	}
+	return n, s[pos:], true
+}
+
+// parseIntervalParts parses the interval in any PostgreSQL IntervalStyle
+// (postgres, postgres_verbose, sql_standard, iso_8601) or ISO 8601 duration
+func parseIntervalParts(s string) (intervalParts, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return intervalParts{}, &DurationParseError{Input: s, Pos: 0, Msg: "missing value"}
+	}
+	offset := strings.Index(s, trimmed)
+	if trimmed[0] == 'P' || len(trimmed) > 1 && (trimmed[0] == '-' || trimmed[0] == '+') && trimmed[1] == 'P' {
+		return parseISO8601Interval(s, trimmed, offset)
+	}
+	return parsePostgresInterval(s)
+}
+
+type intervalToken struct {
+	text string
+	pos  int
+}
+
+type intervalField struct {
+	parts    intervalParts
+	neg      bool
+	signed   bool
+	position int
+}
+
+// parsePostgresInterval parses postgres, postgres_verbose and sql_standard styles
+//
+//	1 year 2 mons 3 days 04:05:06.789
+//	@ 1 year 2 mons -3 days 4 hours 5 mins 6.789 secs ago
+//	1-2 -3 4:05:06.789
+func parsePostgresInterval(s string) (intervalParts, error) {
+	var (
+		tokens    = splitIntervalTokens(s)
+		fields    []intervalField
+		unitWords = false
+		ago       = false
+	)
+	if len(tokens) > 0 && tokens[0].text == "@" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) > 0 && strings.EqualFold(tokens[len(tokens)-1].text, "ago") {
+		tokens, ago = tokens[:len(tokens)-1], true
+	}
+	if len(tokens) == 0 {
+		return intervalParts{}, &DurationParseError{Input: s, Pos: len(s), Msg: "missing value"}
+	}
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case strings.Contains(tok.text, ":"):
+			field, err := parseIntervalTime(s, tok)
+			if err != nil {
+				return intervalParts{}, err
+			}
+			fields = append(fields, field)
+		case isIntervalYearMonth(tok.text):
+			n, rest, _ := scanIntervalNumber(tok.text)
+			months, err := strconv.ParseInt(rest[1:], 10, 64)
+			if err != nil || months > 11 || n.fraction != "" {
+				return intervalParts{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "invalid year-month value"}
+			}
+			total, ok := mulAddInt64(months, n.integer, 12)
+			if !ok {
+				return intervalParts{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "value overflow"}
+			}
+			p := intervalParts{months: total}
+			if n.neg {
+				p = p.neg()
+			}
+			fields = append(fields, intervalField{parts: p, neg: n.neg, signed: n.signed, position: tok.pos})
+		default:
+			n, rest, ok := scanIntervalNumber(tok.text)
+			if !ok {
+				return intervalParts{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "expected number"}
+			}
+			unit := intervalUnitNone
+			if rest != "" {
+				if unit = intervalUnitNames[strings.ToLower(rest)]; unit == intervalUnitNone {
+					return intervalParts{}, &DurationParseError{Input: s, Pos: tok.pos + len(tok.text) - len(rest), Msg: "unknown unit"}
+				}
+			} else if i+1 < len(tokens) {
+				if unit = intervalUnitNames[strings.ToLower(tokens[i+1].text)]; unit != intervalUnitNone {
+					i++
+				}
+			}
+			if unit == intervalUnitNone {
+				// SQL standard day field before the time or the seconds
+				if i+1 < len(tokens) && strings.Contains(tokens[i+1].text, ":") {
+					unit = intervalUnitDay
+				} else {
+					unit = intervalUnitSecond
+				}
+			} else {
+				unitWords = true
+			}
+			p, ok := n.parts(unit)
+			if !ok {
+				return intervalParts{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "value overflow"}
+			}
+			fields = append(fields, intervalField{parts: p, neg: n.neg, signed: n.signed, position: tok.pos})
+		}
+	}
+
+	// In the SQL standard style the leading minus applies to all fields without explicit sign
+	applyLeadingSign := !unitWords && fields[0].neg
+	for _, field := range fields[1:] {
+		applyLeadingSign = applyLeadingSign && !field.signed
+	}
+	var (
+		result intervalParts
+		ok     bool
+	)
+	for i, field := range fields {
+		if applyLeadingSign && i > 0 {
+			field.parts = field.parts.neg()
+		}
+		if result, ok = result.add(field.parts); !ok {
+			return intervalParts{}, &DurationParseError{Input: s, Pos: field.position, Msg: "value overflow"}
+		}
+	}
+	if ago {
+		result = result.neg()
+	}
+	return result, nil
+}
+
+// parseIntervalTime parses `[+-]hh:mm[:ss[.frac]]`
+func parseIntervalTime(s string, tok intervalToken) (intervalField, error) {
+	text, neg, signed := tok.text, false, false
+	if text[0] == '-' || text[0] == '+' {
+		neg, signed, text = text[0] == '-', true, text[1:]
+	}
+	items := strings.Split(text, ":")
+	if len(items) < 2 || len(items) > 3 {
+		return intervalField{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "invalid time value"}
+	}
+	var nanos int64
+	for i, unit := range []int64{int64(Hour), int64(Minute), int64(Second)}[:len(items)] {
+		n, rest, ok := scanIntervalNumber(items[i])
+		if !ok || rest != "" || n.signed || (n.fraction != "" && i < len(items)-1) {
+			return intervalField{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "invalid time value"}
+		}
+		if nanos, ok = mulAddInt64(nanos, n.fractionOf(unit), 1); ok {
+			nanos, ok = mulAddInt64(nanos, n.integer, unit)
+		}
+		if !ok {
+			return intervalField{}, &DurationParseError{Input: s, Pos: tok.pos, Msg: "value overflow"}
+		}
+	}
+	if neg {
+		nanos = -nanos
+	}
+	return intervalField{parts: intervalParts{nanos: nanos}, neg: neg, signed: signed, position: tok.pos}, nil
+}
+
+// isIntervalYearMonth checks `[+-]Y-M` value of the SQL standard style
+func isIntervalYearMonth(text string) bool {
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		text = text[1:]
+	}
+	i := strings.IndexByte(text, '-')
+	if i <= 0 || i == len(text)-1 {
+		return false
+	}
+	for j := 0; j < len(text); j++ {
+		if j != i && (text[j] < '0' || text[j] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitIntervalTokens splits the string by whitespace keeping token positions
+func splitIntervalTokens(s string) []intervalToken {
+	var tokens []intervalToken
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r' {
+			if start >= 0 {
+				tokens = append(tokens, intervalToken{text: s[start:i], pos: start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return tokens
+}
+
+// parseISO8601Interval parses the ISO 8601 duration with designators
+// `P1Y2M3W4DT5H6M7.5S` or in the alternative format `P0001-02-03T04:05:06`
+func parseISO8601Interval(s, text string, offset int) (intervalParts, error) {
+	var (
+		result intervalParts
+		neg    bool
+		pos    = 1
+	)
+	if text[0] == '-' || text[0] == '+' {
+		neg, pos = text[0] == '-', 2
+	}
+	if pos == len(text) {
+		return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "missing value"}
+	}
+	if alt, ok, err := parseISO8601Alternative(s, text[pos:], offset+pos); ok || err != nil {
+		if neg {
+			alt = alt.neg()
+		}
+		return alt, err
+	}
+	inTime := false
+	for pos < len(text) {
+		if text[pos] == 'T' {
+			if inTime || pos+1 == len(text) {
+				return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "unexpected 'T'"}
+			}
+			inTime = true
+			pos++
+			continue
+		}
+		n, rest, ok := scanIntervalNumber(text[pos:])
+		if !ok {
+			return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "expected number"}
+		}
+		pos = len(text) - len(rest)
+		if rest == "" {
+			return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "missing unit designator"}
+		}
+		var unit intervalUnit
+		switch {
+		case rest[0] == 'Y' && !inTime:
+			unit = intervalUnitYear
+		case rest[0] == 'M' && !inTime:
+			unit = intervalUnitMonth
+		case rest[0] == 'W' && !inTime:
+			unit = intervalUnitWeek
+		case rest[0] == 'D' && !inTime:
+			unit = intervalUnitDay
+		case rest[0] == 'H' && inTime:
+			unit = intervalUnitHour
+		case rest[0] == 'M' && inTime:
+			unit = intervalUnitMinute
+		case rest[0] == 'S' && inTime:
+			unit = intervalUnitSecond
+		default:
+			return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "unknown unit designator"}
+		}
+		p, ok := n.parts(unit)
+		if ok {
+			result, ok = result.add(p)
+		}
+		if !ok {
+			return result, &DurationParseError{Input: s, Pos: offset + pos, Msg: "value overflow"}
+		}
+		pos++
+	}
+	if neg {
+		result = result.neg()
+	}
+	return result, nil
+}
+
+// parseISO8601Alternative parses `YYYY-MM-DDThh:mm:ss` part after `P`
+func parseISO8601Alternative(s, text string, offset int) (intervalParts, bool, error) {
+	date, clock, hasTime := strings.Cut(text, "T")
+	if strings.ContainsAny(text, "YMWDHS") || !strings.Contains(date, "-") && !strings.Contains(clock, ":") {
+		return intervalParts{}, false, nil
+	}
+	var result intervalParts
+	if date != "" {
+		items := strings.Split(date, "-")
+		if len(items) != 3 {
+			return result, true, &DurationParseError{Input: s, Pos: offset, Msg: "invalid date value"}
+		}
+		values := make([]int64, 3)
+		for i, item := range items {
+			v, err := strconv.ParseInt(item, 10, 64)
+			if err != nil || item == "" || item[0] == '-' || item[0] == '+' {
+				return result, true, &DurationParseError{Input: s, Pos: offset, Msg: "invalid date value"}
+			}
+			values[i] = v
+		}
+		months, ok := mulAddInt64(values[1], values[0], 12)
+		if !ok {
+			return result, true, &DurationParseError{Input: s, Pos: offset, Msg: "value overflow"}
+		}
+		result.months, result.days = months, values[2]
+	}
+	if hasTime {
+		field, err := parseIntervalTime(s, intervalToken{text: clock, pos: offset + len(date) + 1})
+		if err != nil || field.signed {
+			return result, true, &DurationParseError{Input: s, Pos: offset + len(date) + 1, Msg: "invalid time value"}
+		}
+		result.nanos = field.parts.nanos
+	}
+	return result, true, nil
+}
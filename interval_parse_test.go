@@ -0,0 +1,138 @@
+package gosql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		value  string
+		target Duration
+	}{
+		// postgres
+		{"00:00:00", 0},
+		{"1 day 02:03:04.5", Day + 2*Hour + 3*Minute + 4500*Millisecond},
+		{"3 days", 3 * Day},
+		{"1 year 2 mons 3 days 04:05:06", 14*Month + 3*Day + 4*Hour + 5*Minute + 6*Second},
+		{"-1 days +02:03:04", -Day + 2*Hour + 3*Minute + 4*Second},
+		{"-1 days -02:03:04", -Day - 2*Hour - 3*Minute - 4*Second},
+		{"100:00:00", 100 * Hour},
+		{"1.5 days", Day + 12*Hour},
+		{"1.5 mons", Month + 15*Day},
+		{"11 mons", 11 * Month},
+		{"12 mons", 12 * Month},
+		{"1 year", 12 * Month},
+		{"1 week 1d", 8 * Day},
+		// postgres_verbose
+		{"@ 1 day 2 hours 3 mins 4.5 secs", Day + 2*Hour + 3*Minute + 4500*Millisecond},
+		{"@ 1 hour ago", -Hour},
+		{"@ 1 day -2 hours ago", -Day + 2*Hour},
+		{"@ 0", 0},
+		// sql_standard
+		{"1-2", 14 * Month},
+		{"3 4:05:06", 3*Day + 4*Hour + 5*Minute + 6*Second},
+		{"-1-2 +3 -4:05:06", -14*Month + 3*Day - 4*Hour - 5*Minute - 6*Second},
+		{"-1-2 3 4:05:06", -14*Month - 3*Day - 4*Hour - 5*Minute - 6*Second},
+		{"-0:00:01.5", -1500 * Millisecond},
+		// iso_8601
+		{"P1DT2H", Day + 2*Hour},
+		{"PT0S", 0},
+		{"P1Y2M3DT4H5M6.5S", 14*Month + 3*Day + 4*Hour + 5*Minute + 6500*Millisecond},
+		{"P-1DT-2H", -Day - 2*Hour},
+		{"P2W", 2 * Week},
+		{"-P1D", -Day},
+		{"P0001-02-03T04:05:06", 14*Month + 3*Day + 4*Hour + 5*Minute + 6*Second},
+		{"PT1.5M", 90 * Second},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := ParseInterval(test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.target, d)
+
+			var sd Duration
+			assert.NoError(t, sd.Scan([]byte(test.value)))
+			assert.Equal(t, test.target, sd)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, value := range []string{"", "@", "1 parsec", "P", "P1", "P1H", "PT", "PT1D", "1:2:3:4", "abc", "1-13", "P1-2"} {
+			_, err := ParseInterval(value)
+			assert.Error(t, err, value)
+			assert.True(t, errors.Is(err, ErrInvalidDuration), value)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		for _, value := range []string{
+			"3000000000000 hours", "9223372036854775807 secs", "10000000000 secs", "9223372036854775807 years",
+			"2562048:00:00", "PT3000000000H", "1000000000000 weeks", "768614336404564650-0", "P768614336404564650-00-00",
+			"5000000000 secs 5000000000 secs", "106751 days 23:47:17",
+		} {
+			_, err := ParseInterval(value)
+			assert.Error(t, err, value)
+			assert.ErrorIs(t, err, ErrInvalidDuration, value)
+		}
+		_, err := ParseInterval("3000000000000 hours")
+		assert.Contains(t, err.Error(), "value overflow")
+	})
+
+	t.Run("scan:fallback_error", func(t *testing.T) {
+		var d Duration
+		err := d.Scan("1 parsec")
+		assert.True(t, errors.Is(err, ErrInvalidDuration))
+		assert.Contains(t, err.Error(), "unknown unit")
+	})
+}
+
+func TestIntervalFormat(t *testing.T) {
+	tests := []struct {
+		format DurationFormatter
+		value  Duration
+		target string
+	}{
+		{PostgresIntervalFormat, 0, "00:00:00"},
+		{PostgresIntervalFormat, Day + 2*Hour + 3*Minute + 4500*Millisecond, "1 day 02:03:04.5"},
+		{PostgresIntervalFormat, 3 * Day, "3 days"},
+		{PostgresIntervalFormat, -Day - 2*Hour, "-1 days -02:00:00"},
+		{PostgresIntervalFormat, 100*Hour + Microsecond, "4 days 04:00:00.000001"},
+		{PostgresIntervalFormat, -1500 * Millisecond, "-00:00:01.5"},
+		{ISO8601DurationFormat, 0, "PT0S"},
+		{ISO8601DurationFormat, Day + 2*Hour, "P1DT2H"},
+		{ISO8601DurationFormat, 3*Day + 4*Hour + 5*Minute + 6500*Millisecond, "P3DT4H5M6.5S"},
+		{ISO8601DurationFormat, -Day - 2*Hour, "P-1DT-2H"},
+		{ISO8601DurationFormat, 90 * Second, "PT1M30S"},
+		{ISO8601DurationFormat, 2 * Week, "P14D"},
+	}
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			assert.Equal(t, test.target, test.format.Format(test.value))
+			d, err := ParseInterval(test.target)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.value, d, "round-trip")
+			}
+		})
+	}
+
+	t.Run("formatter", func(t *testing.T) {
+		defer SetDurationFormatter(nil)
+
+		SetDurationFormatter(PostgresIntervalFormat)
+		v, err := (Day + Hour).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "1 day 01:00:00", v)
+
+		SetDurationFormatter(ISO8601DurationFormat)
+		data, err := (Day + Hour).MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `"P1DT1H"`, string(data))
+
+		var d Duration
+		assert.NoError(t, d.UnmarshalJSON(data))
+		assert.Equal(t, Day+Hour, d)
+	})
+}
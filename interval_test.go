@@ -0,0 +1,123 @@
+package gosql
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		value    Interval
+		postgres string
+		iso      string
+	}{
+		{Interval{}, "00:00:00", "PT0S"},
+		{Interval{Months: 14, Days: 3, Microseconds: 4*3600e6 + 5*60e6 + 6.5e6}, "1 year 2 mons 3 days 04:05:06.5", "P1Y2M3DT4H5M6.5S"},
+		{Interval{Months: 1}, "1 mon", "P1M"},
+		{Interval{Days: -1, Microseconds: 2 * 3600e6}, "-1 days +02:00:00", "P-1DT2H"},
+		{Interval{Months: -14, Days: 3, Microseconds: -1}, "-1 years -2 mons +3 days -00:00:00.000001", "P-1Y-2M3DT-0.000001S"},
+		{Interval{Microseconds: 100 * 3600e6}, "100:00:00", "PT100H"},
+	}
+	for _, test := range tests {
+		t.Run(test.postgres, func(t *testing.T) {
+			assert.Equal(t, test.postgres, test.value.String())
+			assert.Equal(t, test.iso, test.value.ISO8601())
+
+			v, err := ParseIntervalValue(test.postgres)
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, v)
+
+			v, err = ParseIntervalValue(test.iso)
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, v)
+
+			var scanned Interval
+			assert.NoError(t, scanned.Scan([]byte(test.postgres)))
+			assert.Equal(t, test.value, scanned)
+
+			val, err := test.value.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.postgres, val)
+		})
+	}
+
+	t.Run("large", func(t *testing.T) {
+		// the time part longer than the nanosecond duration range
+		v := Interval{Microseconds: 3000000*3600e6 + 1}
+		assert.Equal(t, "3000000:00:00.000001", v.String())
+		assert.Equal(t, "PT3000000H0.000001S", v.ISO8601())
+		assert.Equal(t, "-3000000:00:00.000001", Interval{Microseconds: -v.Microseconds}.String())
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		assert.Equal(t, NewInterval(1, 2, 3, 4*Hour+5*Minute+6*Second), MustParseIntervalValue("1-2 3 4:05:06"))
+		assert.Equal(t, Interval{Days: -1, Microseconds: -3600e6}, MustParseIntervalValue("@ 1 day 1 hour ago"))
+		assert.Equal(t, Interval{Microseconds: 1}, MustParseIntervalValue("0.0000005 secs"))
+		_, err := ParseIntervalValue("1 parsec")
+		assert.True(t, errors.Is(err, ErrInvalidDuration))
+		assert.Panics(t, func() { MustParseIntervalValue("") })
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var v Interval
+		assert.NoError(t, v.Scan(90*time.Minute))
+		assert.Equal(t, Interval{Microseconds: 90 * 60e6}, v)
+		assert.ErrorIs(t, v.Scan(nil), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, v.Scan(1.5), ErrInvalidScanValue)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(struct{ Period Interval }{NewInterval(0, 1, 2, Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Period":"P1M2DT1H"}`, string(data))
+
+		var v struct{ Period Interval }
+		assert.NoError(t, json.Unmarshal([]byte(`{"Period":"1 mon 2 days 01:00:00"}`), &v))
+		assert.Equal(t, NewInterval(0, 1, 2, Hour), v.Period)
+		assert.Error(t, json.Unmarshal([]byte(`{"Period":null}`), &v))
+		assert.Error(t, json.Unmarshal([]byte(`{"Period":10}`), &v))
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		assert.Equal(t, 14*Month+3*Day+Hour, NewInterval(1, 2, 3, Hour).Duration())
+		assert.Equal(t, -Day, Interval{Days: -1}.Duration())
+		assert.Equal(t, Duration(1<<63-1), Interval{Months: 1 << 30}.Duration())
+		assert.Equal(t, Duration(-1<<63), Interval{Months: -1 << 30}.Duration())
+		assert.Equal(t, Interval{Months: -1, Days: -2, Microseconds: -3}, Interval{Months: 1, Days: 2, Microseconds: 3}.Neg())
+		assert.True(t, Interval{}.IsZero())
+	})
+
+	t.Run("add_to", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			ny = time.FixedZone("EST", -5*3600)
+		}
+		date := func(y int, m time.Month, d, h int, loc *time.Location) time.Time {
+			return time.Date(y, m, d, h, 0, 0, 0, loc)
+		}
+		tests := []struct {
+			name     string
+			interval Interval
+			from     time.Time
+			target   time.Time
+		}{
+			{"month_end", Interval{Months: 1}, date(2024, 1, 31, 10, time.UTC), date(2024, 2, 29, 10, time.UTC)},
+			{"month_end_no_leap", Interval{Months: 1}, date(2023, 1, 31, 10, time.UTC), date(2023, 2, 28, 10, time.UTC)},
+			{"year_leap", Interval{Months: 12}, date(2024, 2, 29, 0, time.UTC), date(2025, 2, 28, 0, time.UTC)},
+			{"month_back", Interval{Months: -1}, date(2024, 3, 31, 0, time.UTC), date(2024, 2, 29, 0, time.UTC)},
+			{"months_back_year", Interval{Months: -13}, date(2024, 1, 15, 0, time.UTC), date(2022, 12, 15, 0, time.UTC)},
+			{"month_then_day", Interval{Months: 1, Days: 1}, date(2024, 1, 31, 0, time.UTC), date(2024, 3, 1, 0, time.UTC)},
+			{"time", Interval{Microseconds: 90 * 60e6}, date(2024, 1, 1, 23, time.UTC), time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC)},
+			{"dst_day", Interval{Days: 1}, date(2024, 3, 9, 12, ny), date(2024, 3, 10, 12, ny)},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.True(t, test.target.Equal(test.interval.AddTo(test.from)), test.interval.AddTo(test.from).String())
+			})
+		}
+	})
+}
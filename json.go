@@ -61,7 +61,7 @@ func (f *JSON[T]) SetValue(value any) error {
 	case []byte:
 		return f.UnmarshalJSON(vl)
 	default:
-		data, err := json.Marshal(value)
+		data, err := jsonCodecOf[T]().Marshal(value)
 		if err != nil {
 			return err
 		}
@@ -98,7 +98,7 @@ func (f *JSON[T]) Scan(value any) error {
 
 // MarshalJSON implements the json.Marshaler
 func (f JSON[T]) MarshalJSON() ([]byte, error) {
-	return json.Marshal(f.Data)
+	return jsonCodecOf[T]().Marshal(f.Data)
 }
 
 // UnmarshalJSON implements the json.Unmarshaller
@@ -110,7 +110,7 @@ func (f *JSON[T]) UnmarshalJSON(data []byte) error {
 	if data = bytes.TrimSpace(data); len(data) == 0 {
 		return nil
 	}
-	return json.Unmarshal(data, &f.Data)
+	return unmarshalJSONData[T](data, &f.Data)
 }
 
 // DecodeValue implements the gocast.Decoder
@@ -92,13 +92,13 @@ func (f JSONArray[T]) MarshalJSON() ([]byte, error) {
 	if len(f) == 0 {
 		return []byte("[]"), nil
 	}
-	return json.Marshal([]T(f))
+	return jsonCodecOf[T]().Marshal([]T(f))
 }
 
 // UnmarshalJSON implements the json.Unmarshaller
 func (f *JSONArray[T]) UnmarshalJSON(b []byte) error {
 	var res []T
-	if err := json.Unmarshal(b, &res); err != nil {
+	if err := unmarshalJSONData[T](b, &res); err != nil {
 		return err
 	}
 	*f = append((*f)[:0], res...)
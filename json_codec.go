@@ -0,0 +1,79 @@
+package gosql
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// JSONCodec is the JSON engine of JSON, NullableJSON and JSONArray types.
+// Any library with the encoding/json compatible API can be used, like jsoniter,
+// goccy/go-json or sonic.
+//
+//	type sonicCodec struct{}
+//
+//	func (sonicCodec) Marshal(v any) ([]byte, error)      { return sonic.Marshal(v) }
+//	func (sonicCodec) Unmarshal(data []byte, v any) error { return sonic.Unmarshal(data, v) }
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// StdJSONCodec is the encoding/json engine
+type StdJSONCodec struct{}
+
+// Marshal implements JSONCodec
+func (StdJSONCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal implements JSONCodec
+func (StdJSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+var (
+	jsonCodec atomic.Value
+
+	jsonTypeCodecs    sync.Map // reflect.Type -> JSONCodec
+	hasJSONTypeCodecs atomic.Bool
+)
+
+// SetJSONCodec defines the default JSON engine of JSON types,
+// StdJSONCodec is used by default or if the codec is nil
+//
+//	gosql.SetJSONCodec(sonicCodec{})
+func SetJSONCodec(c JSONCodec) {
+	if c == nil {
+		c = StdJSONCodec{}
+	}
+	jsonCodec.Store(&c)
+}
+
+// RegisterJSONCodec defines the JSON engine of JSON[T], NullableJSON[T] and JSONArray[T]
+// types with the data type T, nil codec removes the registration
+//
+//	gosql.RegisterJSONCodec[LargeDocument](sonicCodec{})
+func RegisterJSONCodec[T any](c JSONCodec) {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+	if c == nil {
+		jsonTypeCodecs.Delete(key)
+		return
+	}
+	jsonTypeCodecs.Store(key, c)
+	hasJSONTypeCodecs.Store(true)
+}
+
+func currentJSONCodec() JSONCodec {
+	if c, _ := jsonCodec.Load().(*JSONCodec); c != nil {
+		return *c
+	}
+	return StdJSONCodec{}
+}
+
+// jsonCodecOf returns the JSON engine of the data type T
+func jsonCodecOf[T any]() JSONCodec {
+	if hasJSONTypeCodecs.Load() {
+		if c, ok := jsonTypeCodecs.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+			return c.(JSONCodec)
+		}
+	}
+	return currentJSONCodec()
+}
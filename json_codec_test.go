@@ -0,0 +1,95 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingJSONCodec counts calls of the std codec
+type countingJSONCodec struct {
+	marshal   *int
+	unmarshal *int
+}
+
+func newCountingJSONCodec() countingJSONCodec {
+	return countingJSONCodec{marshal: new(int), unmarshal: new(int)}
+}
+
+func (c countingJSONCodec) Marshal(v any) ([]byte, error) {
+	*c.marshal++
+	return json.Marshal(v)
+}
+
+func (c countingJSONCodec) Unmarshal(data []byte, v any) error {
+	*c.unmarshal++
+	return json.Unmarshal(data, v)
+}
+
+func TestJSONCodec(t *testing.T) {
+	type doc struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("global", func(t *testing.T) {
+		codec := newCountingJSONCodec()
+		SetJSONCodec(codec)
+		defer SetJSONCodec(nil)
+
+		var js JSON[doc]
+		assert.NoError(t, js.Scan(`{"name":"a"}`))
+		_, err := js.Value()
+		assert.NoError(t, err)
+
+		var njs NullableJSON[doc]
+		assert.NoError(t, njs.Scan(`{"name":"b"}`))
+		_, err = njs.Value()
+		assert.NoError(t, err)
+
+		var arr JSONArray[doc]
+		assert.NoError(t, arr.Scan(`[{"name":"c"}]`))
+		_, err = arr.Value()
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, *codec.marshal)
+		assert.Equal(t, 3, *codec.unmarshal)
+
+		// SetValue round-trip
+		assert.NoError(t, js.SetValue(map[string]any{"name": "d"}))
+		assert.NoError(t, njs.SetValue(map[string]any{"name": "e"}))
+		assert.Equal(t, "d", js.Data.Name)
+		assert.Equal(t, "e", njs.Data.Name)
+		assert.Equal(t, 5, *codec.marshal)
+		assert.Equal(t, 5, *codec.unmarshal)
+	})
+
+	t.Run("type", func(t *testing.T) {
+		global, typed := newCountingJSONCodec(), newCountingJSONCodec()
+		SetJSONCodec(global)
+		RegisterJSONCodec[doc](typed)
+		defer func() {
+			SetJSONCodec(nil)
+			RegisterJSONCodec[doc](nil)
+		}()
+
+		var js JSON[doc]
+		assert.NoError(t, js.Scan(`{"name":"a"}`))
+		var other JSON[map[string]any]
+		assert.NoError(t, other.Scan(`{"name":"a"}`))
+		var arr NullableJSONArray[doc]
+		assert.NoError(t, arr.Scan(`[{"name":"a"}]`))
+
+		assert.Equal(t, 2, *typed.unmarshal)
+		assert.Equal(t, 1, *global.unmarshal)
+
+		RegisterJSONCodec[doc](nil)
+		assert.NoError(t, js.Scan(`{"name":"b"}`))
+		assert.Equal(t, 2, *typed.unmarshal)
+		assert.Equal(t, 2, *global.unmarshal)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, StdJSONCodec{}, currentJSONCodec())
+	})
+}
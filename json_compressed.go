@@ -0,0 +1,254 @@
+package gosql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// JSONCompression is the compression algorithm of CompressedJSON
+type JSONCompression byte
+
+// Set of compression algorithms, gzip is supported by default
+// and zstd, snappy are registered by the compress subpackage
+const (
+	JSONCompressionGzip   JSONCompression = 1
+	JSONCompressionZstd   JSONCompression = 2
+	JSONCompressionSnappy JSONCompression = 3
+)
+
+// String implements fmt.Stringer
+func (c JSONCompression) String() string {
+	switch c {
+	case JSONCompressionGzip:
+		return "gzip"
+	case JSONCompressionZstd:
+		return "zstd"
+	case JSONCompressionSnappy:
+		return "snappy"
+	}
+	return fmt.Sprintf("compression(%d)", byte(c))
+}
+
+// JSONCompressor compresses and decompresses documents of CompressedJSON
+type JSONCompressor interface {
+	// Compress the data with the level, 0 is the default level of the algorithm
+	Compress(data []byte, level int) ([]byte, error)
+
+	// Decompress the data, the result longer than the limit returns ErrJSONTooLarge,
+	// 0 limit is unlimited
+	Decompress(data []byte, limit int) ([]byte, error)
+}
+
+// JSONCompressionOptions of CompressedJSON values
+type JSONCompressionOptions struct {
+	// Algorithm of the compression, gzip by default
+	Algorithm JSONCompression
+
+	// Level of the compression, 0 is the default level of the algorithm
+	Level int
+
+	// Threshold is the minimal size of the compressed document,
+	// smaller documents are stored as plain JSON
+	Threshold int
+}
+
+// The compressed document starts with the header: 0xC0 'J' version algorithm.
+// 0xC0 is never used in UTF-8, so the header is not the start of the plain JSON document.
+const (
+	compressedJSONMagic0  = 0xC0
+	compressedJSONMagic1  = 'J'
+	compressedJSONVersion = 1
+	compressedJSONHeader  = 4
+)
+
+var (
+	jsonCompressors sync.Map // JSONCompression -> JSONCompressor
+
+	jsonCompressionOptions atomic.Value
+
+	jsonTypeCompressionOptions    sync.Map // reflect.Type -> JSONCompressionOptions
+	hasJSONTypeCompressionOptions atomic.Bool
+)
+
+func init() {
+	RegisterJSONCompressor(JSONCompressionGzip, GzipJSONCompressor{})
+}
+
+// RegisterJSONCompressor defines the compressor of the algorithm, nil compressor removes the registration
+func RegisterJSONCompressor(algorithm JSONCompression, c JSONCompressor) {
+	if c == nil {
+		jsonCompressors.Delete(algorithm)
+		return
+	}
+	jsonCompressors.Store(algorithm, c)
+}
+
+// SetJSONCompressionOptions defines the default compression options of CompressedJSON types
+//
+//	gosql.SetJSONCompressionOptions(gosql.JSONCompressionOptions{Level: 6, Threshold: 1024})
+func SetJSONCompressionOptions(opts JSONCompressionOptions) {
+	jsonCompressionOptions.Store(&opts)
+}
+
+// RegisterJSONCompressionOptions defines the compression options of CompressedJSON[T]
+// with the data type T, nil options remove the registration
+//
+//	gosql.RegisterJSONCompressionOptions[Report](&gosql.JSONCompressionOptions{Algorithm: gosql.JSONCompressionZstd})
+func RegisterJSONCompressionOptions[T any](opts *JSONCompressionOptions) {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+	if opts == nil {
+		jsonTypeCompressionOptions.Delete(key)
+		return
+	}
+	jsonTypeCompressionOptions.Store(key, *opts)
+	hasJSONTypeCompressionOptions.Store(true)
+}
+
+// jsonCompressionOptionsOf returns the compression options of the data type T
+func jsonCompressionOptionsOf[T any]() JSONCompressionOptions {
+	if hasJSONTypeCompressionOptions.Load() {
+		if opts, ok := jsonTypeCompressionOptions.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+			return opts.(JSONCompressionOptions)
+		}
+	}
+	if opts, _ := jsonCompressionOptions.Load().(*JSONCompressionOptions); opts != nil {
+		return *opts
+	}
+	return JSONCompressionOptions{}
+}
+
+// CompressedJSON field stores the compressed JSON document in the binary column
+// (bytea, BLOB) and decodes plain JSON documents without the compression header
+type CompressedJSON[T any] struct {
+	JSON[T]
+}
+
+// Value implements the driver.Valuer interface, the compressed document
+func (f CompressedJSON[T]) Value() (driver.Value, error) {
+	data, err := f.JSON.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return CompressJSON(data, jsonCompressionOptionsOf[T]())
+}
+
+// Scan implements the sql.Scanner interface, compressed and plain documents are supported
+func (f *CompressedJSON[T]) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case nil:
+		return ErrNullValueNotAllowed
+	default:
+		return ErrInvalidScan
+	}
+	data, err := DecompressJSON(data, jsonDecodeOptionsOf[T]().MaxBytes)
+	if err != nil {
+		return err
+	}
+	return f.JSON.UnmarshalJSON(data)
+}
+
+// CompressJSON returns the document with the compression header,
+// documents smaller than the threshold or not reduced by the compression are returned as is
+func CompressJSON(data []byte, opts JSONCompressionOptions) ([]byte, error) {
+	if len(data) < opts.Threshold {
+		return data, nil
+	}
+	algorithm := opts.Algorithm
+	if algorithm == 0 {
+		algorithm = JSONCompressionGzip
+	}
+	c, err := jsonCompressorOf(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := c.Compress(data, opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed)+compressedJSONHeader >= len(data) {
+		return data, nil
+	}
+	res := make([]byte, 0, compressedJSONHeader+len(compressed))
+	res = append(res, compressedJSONMagic0, compressedJSONMagic1, compressedJSONVersion, byte(algorithm))
+	return append(res, compressed...), nil
+}
+
+// DecompressJSON returns the decompressed document or the data as is without the compression header,
+// the document longer than the limit returns ErrJSONTooLarge, 0 limit is unlimited
+func DecompressJSON(data []byte, limit int) ([]byte, error) {
+	if len(data) < compressedJSONHeader || data[0] != compressedJSONMagic0 || data[1] != compressedJSONMagic1 {
+		return data, nil
+	}
+	if data[2] != compressedJSONVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidCompressedJSON, data[2])
+	}
+	c, err := jsonCompressorOf(JSONCompression(data[3]))
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Decompress(data[compressedJSONHeader:], limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCompressedJSON, err)
+	}
+	return res, nil
+}
+
+func jsonCompressorOf(algorithm JSONCompression) (JSONCompressor, error) {
+	if c, ok := jsonCompressors.Load(algorithm); ok {
+		return c.(JSONCompressor), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, algorithm)
+}
+
+// GzipJSONCompressor is the gzip compressor of the standard library
+type GzipJSONCompressor struct{}
+
+// Compress implements JSONCompressor
+func (GzipJSONCompressor) Compress(data []byte, level int) ([]byte, error) {
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress implements JSONCompressor
+func (GzipJSONCompressor) Decompress(data []byte, limit int) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var src io.Reader = r
+	if limit > 0 {
+		src = io.LimitReader(r, int64(limit)+1)
+	}
+	res, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(res) > limit {
+		return nil, ErrJSONTooLarge
+	}
+	return res, r.Close()
+}
@@ -0,0 +1,92 @@
+package gosql
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type compressedTestReport struct {
+	Name  string   `json:"name"`
+	Lines []string `json:"lines"`
+}
+
+func newCompressedTestReport(lines int) compressedTestReport {
+	report := compressedTestReport{Name: "report"}
+	for i := 0; i < lines; i++ {
+		report.Lines = append(report.Lines, "the same line of the report")
+	}
+	return report
+}
+
+func TestCompressedJSON(t *testing.T) {
+	t.Run("compress", func(t *testing.T) {
+		var js CompressedJSON[compressedTestReport]
+		js.Data = newCompressedTestReport(100)
+		plain, _ := json.Marshal(js.Data)
+
+		v, err := js.Value()
+		assert.NoError(t, err)
+		data, _ := v.([]byte)
+		if assert.True(t, len(data) > 4) {
+			assert.Equal(t, []byte{0xC0, 'J', 1, byte(JSONCompressionGzip)}, data[:4])
+			assert.Less(t, len(data), len(plain))
+		}
+
+		var res CompressedJSON[compressedTestReport]
+		assert.NoError(t, res.Scan(data))
+		assert.Equal(t, js.Data, res.Data)
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		var js CompressedJSON[compressedTestReport]
+		assert.NoError(t, js.Scan(`{"name":"a","lines":["b"]}`))
+		assert.Equal(t, compressedTestReport{Name: "a", Lines: []string{"b"}}, js.Data)
+
+		// too small document is not reduced by the compression
+		v, err := js.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"name":"a","lines":["b"]}`), v)
+
+		assert.ErrorIs(t, js.Scan(nil), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, js.Scan(1), ErrInvalidScan)
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		RegisterJSONCompressionOptions[compressedTestReport](&JSONCompressionOptions{Level: 9, Threshold: 1 << 20})
+		defer RegisterJSONCompressionOptions[compressedTestReport](nil)
+
+		js := CompressedJSON[compressedTestReport]{}
+		js.Data = newCompressedTestReport(100)
+		plain, _ := json.Marshal(js.Data)
+		v, err := js.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, plain, v)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		data, err := CompressJSON([]byte(`"`+strings.Repeat("a", 1000)+`"`), JSONCompressionOptions{})
+		assert.NoError(t, err)
+		assert.Less(t, len(data), 100)
+
+		RegisterJSONDecodeOptions[string](&JSONDecodeOptions{MaxBytes: 100})
+		defer RegisterJSONDecodeOptions[string](nil)
+		var js CompressedJSON[string]
+		assert.ErrorIs(t, js.Scan(data), ErrJSONTooLarge)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var js CompressedJSON[compressedTestReport]
+		assert.ErrorIs(t, js.Scan([]byte{0xC0, 'J', 1, 1, 'x'}), ErrInvalidCompressedJSON)
+		assert.ErrorIs(t, js.Scan([]byte{0xC0, 'J', 2, 1, 'x'}), ErrInvalidCompressedJSON)
+		assert.ErrorIs(t, js.Scan([]byte{0xC0, 'J', 1, 100, 'x'}), ErrUnsupportedCompression)
+
+		_, err := CompressJSON(bytes.Repeat([]byte("a"), 100), JSONCompressionOptions{Algorithm: 100})
+		assert.ErrorIs(t, err, ErrUnsupportedCompression)
+		_, err = CompressJSON(bytes.Repeat([]byte("a"), 100), JSONCompressionOptions{Level: 100})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,380 @@
+package gosql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// JSONDecodeOptions of the strict decoding of JSON, NullableJSON and JSONArray types
+type JSONDecodeOptions struct {
+	// DisallowUnknownFields rejects object keys which do not match any struct field
+	DisallowUnknownFields bool
+
+	// UseNumber decodes numbers into interface values as json.Number instead of float64
+	UseNumber bool
+
+	// MaxBytes is the maximum size of the document, 0 is unlimited
+	MaxBytes int
+
+	// MaxDepth is the maximum nesting of objects and arrays, 0 is unlimited
+	MaxDepth int
+}
+
+// IsZero returns true if no option is set
+func (o JSONDecodeOptions) IsZero() bool {
+	return o == JSONDecodeOptions{}
+}
+
+// JSONOptionsCodec is implemented by JSONCodec which supports DisallowUnknownFields and UseNumber options,
+// otherwise StdJSONCodec is used to decode with these options
+type JSONOptionsCodec interface {
+	UnmarshalWithOptions(data []byte, v any, opts JSONDecodeOptions) error
+}
+
+// UnmarshalWithOptions implements JSONOptionsCodec
+func (StdJSONCodec) UnmarshalWithOptions(data []byte, v any, opts JSONDecodeOptions) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if opts.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("json: invalid data after top-level value")
+	}
+	return nil
+}
+
+// JSONDecodeError is the error of the strict decoding with the path of the failed value
+// like `settings.items[2].name`
+type JSONDecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *JSONDecodeError) Error() string {
+	if e.Path == "" {
+		return "json: " + strings.TrimPrefix(e.Err.Error(), "json: ")
+	}
+	return "json: " + e.Path + ": " + strings.TrimPrefix(e.Err.Error(), "json: ")
+}
+
+func (e *JSONDecodeError) Unwrap() error { return e.Err }
+
+var (
+	jsonDecodeOptions atomic.Value
+
+	jsonTypeDecodeOptions    sync.Map // reflect.Type -> JSONDecodeOptions
+	hasJSONTypeDecodeOptions atomic.Bool
+
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// SetJSONDecodeOptions defines the default decoding options of JSON types,
+// the zero options disable the strict decoding
+//
+//	gosql.SetJSONDecodeOptions(gosql.JSONDecodeOptions{DisallowUnknownFields: true, MaxBytes: 1 << 20})
+func SetJSONDecodeOptions(opts JSONDecodeOptions) {
+	jsonDecodeOptions.Store(&opts)
+}
+
+// RegisterJSONDecodeOptions defines the decoding options of JSON[T], NullableJSON[T] and JSONArray[T]
+// types with the data type T, nil options remove the registration
+//
+//	gosql.RegisterJSONDecodeOptions[Config](&gosql.JSONDecodeOptions{DisallowUnknownFields: true})
+func RegisterJSONDecodeOptions[T any](opts *JSONDecodeOptions) {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+	if opts == nil {
+		jsonTypeDecodeOptions.Delete(key)
+		return
+	}
+	jsonTypeDecodeOptions.Store(key, *opts)
+	hasJSONTypeDecodeOptions.Store(true)
+}
+
+// jsonDecodeOptionsOf returns the decoding options of the data type T
+func jsonDecodeOptionsOf[T any]() JSONDecodeOptions {
+	if hasJSONTypeDecodeOptions.Load() {
+		if opts, ok := jsonTypeDecodeOptions.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+			return opts.(JSONDecodeOptions)
+		}
+	}
+	if opts, _ := jsonDecodeOptions.Load().(*JSONDecodeOptions); opts != nil {
+		return *opts
+	}
+	return JSONDecodeOptions{}
+}
+
+// unmarshalJSONData decodes the data of the type T by the codec and the decoding options of T
+func unmarshalJSONData[T any](data []byte, target any) error {
+	codec, opts := jsonCodecOf[T](), jsonDecodeOptionsOf[T]()
+	if opts.IsZero() {
+		return codec.Unmarshal(data, target)
+	}
+	if opts.MaxBytes > 0 && len(data) > opts.MaxBytes {
+		return &JSONDecodeError{Err: ErrJSONTooLarge}
+	}
+	if opts.MaxDepth > 0 {
+		if err := checkJSONDepth(data, opts.MaxDepth); err != nil {
+			return err
+		}
+	}
+	var err error
+	if opts.DisallowUnknownFields || opts.UseNumber {
+		optsCodec, ok := codec.(JSONOptionsCodec)
+		if !ok {
+			optsCodec = StdJSONCodec{}
+		}
+		err = optsCodec.UnmarshalWithOptions(data, target, opts)
+	} else {
+		err = codec.Unmarshal(data, target)
+	}
+	if err != nil {
+		return jsonDecodeError(err, data, reflect.TypeOf(target).Elem(), opts.DisallowUnknownFields)
+	}
+	return nil
+}
+
+// jsonDecodeError adds the path to the decoding error, the document of the strict decoding
+// is checked for unknown fields because codecs report them by different errors
+func jsonDecodeError(err error, data []byte, t reflect.Type, strict bool) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &JSONDecodeError{Path: strings.TrimPrefix(typeErr.Field, "."), Err: err}
+	}
+	if strict {
+		if path := findUnknownJSONField(data, t, ""); path != "" {
+			return &JSONDecodeError{Path: path, Err: ErrJSONUnknownField}
+		}
+	}
+	return err
+}
+
+// jsonPathFrame is the container of the current value
+type jsonPathFrame struct {
+	array     bool
+	key       string
+	index     int
+	expectKey bool
+}
+
+// checkJSONDepth returns the error with the path of the first container nested deeper than max
+func checkJSONDepth(data []byte, max int) error {
+	var (
+		dec   = json.NewDecoder(bytes.NewReader(data))
+		stack []jsonPathFrame
+	)
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return nil // syntax errors are reported by the decoder
+		}
+		top := len(stack) - 1
+		if top >= 0 && stack[top].expectKey {
+			if key, ok := tok.(string); ok {
+				stack[top].key, stack[top].expectKey = key, false
+				continue
+			}
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			if len(stack) >= max {
+				return &JSONDecodeError{Path: jsonFramesPath(stack), Err: ErrJSONTooDeep}
+			}
+			stack = append(stack, jsonPathFrame{array: tok == json.Delim('['), expectKey: tok == json.Delim('{')})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:top]
+		}
+		// The value is completed, move the parent to the next element
+		if top = len(stack) - 1; top >= 0 {
+			if stack[top].array {
+				stack[top].index++
+			} else {
+				stack[top].expectKey = true
+			}
+		}
+	}
+}
+
+func jsonFramesPath(stack []jsonPathFrame) string {
+	var path strings.Builder
+	for _, frame := range stack {
+		if frame.array {
+			path.WriteString("[" + strconv.Itoa(frame.index) + "]")
+			continue
+		}
+		if path.Len() > 0 {
+			path.WriteByte('.')
+		}
+		path.WriteString(frame.key)
+	}
+	return path.String()
+}
+
+// findUnknownJSONField returns the path of the first object key in the document order
+// which does not match the fields of the type, the key with the name reported by the decoder is preferred
+func findUnknownJSONField(data []byte, t reflect.Type, name string) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return ""
+	}
+	if name != "" {
+		if path := walkUnknownJSONField(doc, t, "", name); path != "" {
+			return path
+		}
+	}
+	return walkUnknownJSONField(doc, t, "", "")
+}
+
+// orderedJSONMember is the member of the object which keeps the document order
+type orderedJSONMember struct {
+	key   string
+	value any
+}
+
+// decodeOrderedJSON decodes the value with objects as []orderedJSONMember
+func decodeOrderedJSON(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		members := []orderedJSONMember{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, orderedJSONMember{key: key.(string), value: value})
+		}
+		_, err = dec.Token()
+		return members, err
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token()
+		return items, err
+	}
+	return tok, nil
+}
+
+// walkUnknownJSONField returns the path of the first unknown key,
+// only keys equal to the name are reported if the name is not empty
+func walkUnknownJSONField(doc any, t reflect.Type, path, name string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return ""
+	}
+	switch val := doc.(type) {
+	case []orderedJSONMember:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonStructFields(t)
+			for _, member := range val {
+				ft, ok := fields[strings.ToLower(member.key)]
+				if !ok {
+					if name == "" || member.key == name {
+						return joinJSONPath(path, member.key)
+					}
+					continue
+				}
+				if p := walkUnknownJSONField(member.value, ft, joinJSONPath(path, member.key), name); p != "" {
+					return p
+				}
+			}
+		case reflect.Map:
+			for _, member := range val {
+				if p := walkUnknownJSONField(member.value, t.Elem(), joinJSONPath(path, member.key), name); p != "" {
+					return p
+				}
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range val {
+				if p := walkUnknownJSONField(item, t.Elem(), path+"["+strconv.Itoa(i)+"]", name); p != "" {
+					return p
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// jsonStructFields returns the types of struct fields by the lowercase JSON name,
+// fields of embedded structs are included if they are not shadowed
+func jsonStructFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	for _, et := range embedded {
+		for name, ft := range jsonStructFields(et) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = ft
+			}
+		}
+	}
+	return fields
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,169 @@
+package gosql
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type strictTestItem struct {
+	Name string `json:"name"`
+}
+
+type strictTestBase struct {
+	ID int `json:"id"`
+}
+
+type strictTestConfig struct {
+	strictTestBase
+	Title string           `json:"title"`
+	Items []strictTestItem `json:"items"`
+	Extra map[string]any   `json:"extra"`
+	Inner struct {
+		Color string `json:"color"`
+	} `json:"inner"`
+}
+
+func TestJSONDecodeOptions(t *testing.T) {
+	RegisterJSONDecodeOptions[strictTestConfig](&JSONDecodeOptions{DisallowUnknownFields: true, UseNumber: true})
+	defer RegisterJSONDecodeOptions[strictTestConfig](nil)
+
+	t.Run("unknown_fields", func(t *testing.T) {
+		var js JSON[strictTestConfig]
+		assert.NoError(t, js.Scan(`{"id":1,"title":"a","items":[{"name":"x"}],"extra":{"n":1},"inner":{"color":"red"}}`))
+		assert.Equal(t, 1, js.Data.ID)
+		assert.Equal(t, json.Number("1"), js.Data.Extra["n"])
+
+		tests := []struct {
+			data string
+			path string
+		}{
+			{`{"titel":"a"}`, "titel"},
+			{`{"inner":{"colour":"red"}}`, "inner.colour"},
+			{`{"items":[{"name":"x"},{"nam":"y"}]}`, "items[1].nam"},
+			// the first unknown field in the document order like encoding/json reports
+			{`{"b":1,"a":2,"c":3,"inner":{"x":1}}`, "b"},
+			{`{"title":"a","inner":{"z":1,"y":2},"d":1}`, "inner.z"},
+		}
+		for _, test := range tests {
+			for i := 0; i < 10; i++ {
+				err := js.Scan(test.data)
+				var decodeErr *JSONDecodeError
+				if assert.ErrorAs(t, err, &decodeErr) {
+					assert.Equal(t, test.path, decodeErr.Path, "deterministic")
+				}
+			}
+			err := js.Scan(test.data)
+			assert.ErrorIs(t, err, ErrJSONUnknownField, test.data)
+			var decodeErr *JSONDecodeError
+			if assert.ErrorAs(t, err, &decodeErr) {
+				assert.Equal(t, test.path, decodeErr.Path)
+			}
+		}
+		assert.EqualError(t, js.Scan(`{"inner":{"colour":"red"}}`), "json: inner.colour: unknown field")
+		// the field reported by the codec is preferred
+		configType := reflect.TypeOf(strictTestConfig{})
+		assert.Equal(t, "inner.c", findUnknownJSONField([]byte(`{"b":1,"inner":{"c":2}}`), configType, "c"))
+		assert.Equal(t, "b", findUnknownJSONField([]byte(`{"b":1,"inner":{"c":2}}`), configType, "x"))
+
+		var arr JSONArray[strictTestConfig]
+		err := arr.Scan(`[{"id":1},{"idx":2}]`)
+		var decodeErr *JSONDecodeError
+		if assert.ErrorAs(t, err, &decodeErr) {
+			assert.Equal(t, "[1].idx", decodeErr.Path)
+		}
+
+		var njs NullableJSON[strictTestConfig]
+		assert.ErrorIs(t, njs.Scan(`{"unknown":1}`), ErrJSONUnknownField)
+		assert.Nil(t, njs.Data)
+	})
+
+	t.Run("type_error", func(t *testing.T) {
+		var js JSON[strictTestConfig]
+		err := js.Scan(`{"inner":{"color":1}}`)
+		var decodeErr *JSONDecodeError
+		if assert.ErrorAs(t, err, &decodeErr) {
+			assert.Equal(t, "inner.color", decodeErr.Path)
+		}
+		var typeErr *json.UnmarshalTypeError
+		assert.ErrorAs(t, err, &typeErr)
+	})
+
+	t.Run("other_types_are_lax", func(t *testing.T) {
+		var js JSON[strictTestItem]
+		assert.NoError(t, js.Scan(`{"name":"a","unknown":1}`))
+	})
+}
+
+func TestJSONDecodeLimits(t *testing.T) {
+	SetJSONDecodeOptions(JSONDecodeOptions{MaxBytes: 64, MaxDepth: 3})
+	defer SetJSONDecodeOptions(JSONDecodeOptions{})
+
+	var js JSON[map[string]any]
+	assert.NoError(t, js.Scan(`{"a":{"b":[1]}}`))
+	assert.ErrorIs(t, js.Scan(`{"a":"`+string(make([]byte, 64))+`"}`), ErrJSONTooLarge)
+
+	err := js.Scan(`{"a":{"b":[1,{"c":2}]}}`)
+	assert.ErrorIs(t, err, ErrJSONTooDeep)
+	var decodeErr *JSONDecodeError
+	if assert.ErrorAs(t, err, &decodeErr) {
+		assert.Equal(t, "a.b[1]", decodeErr.Path)
+	}
+
+	var arr JSONArray[[]int]
+	assert.ErrorIs(t, arr.Scan(`[[1],[[[2]]]]`), ErrJSONTooDeep)
+
+	// The type options replace the global ones
+	RegisterJSONDecodeOptions[map[string]any](&JSONDecodeOptions{})
+	defer RegisterJSONDecodeOptions[map[string]any](nil)
+	assert.NoError(t, js.Scan(`{"a":{"b":[1,{"c":2}]}}`))
+}
+
+// strictTestCodec reports unknown fields by its own error
+type strictTestCodec struct{ StdJSONCodec }
+
+func (c strictTestCodec) UnmarshalWithOptions(data []byte, v any, opts JSONDecodeOptions) error {
+	if err := c.StdJSONCodec.UnmarshalWithOptions(data, v, opts); err != nil {
+		return errors.New("strict codec: the document has an unexpected key")
+	}
+	return nil
+}
+
+func TestJSONDecodeOptionsCodec(t *testing.T) {
+	codec := newCountingJSONCodec()
+	RegisterJSONCodec[strictTestItem](codec)
+	RegisterJSONDecodeOptions[strictTestItem](&JSONDecodeOptions{MaxDepth: 2})
+	defer func() {
+		RegisterJSONCodec[strictTestItem](nil)
+		RegisterJSONDecodeOptions[strictTestItem](nil)
+	}()
+
+	var js JSON[strictTestItem]
+	assert.NoError(t, js.Scan(`{"name":"a"}`))
+	assert.Equal(t, 1, *codec.unmarshal, "the codec is used without DisallowUnknownFields and UseNumber")
+
+	RegisterJSONDecodeOptions[strictTestItem](&JSONDecodeOptions{DisallowUnknownFields: true})
+	assert.ErrorIs(t, js.Scan(`{"name":"a","x":1}`), ErrJSONUnknownField)
+	assert.Equal(t, 1, *codec.unmarshal, "the std codec is used for the codec without options support")
+
+	t.Run("unknown_field_error", func(t *testing.T) {
+		RegisterJSONCodec[strictTestConfig](strictTestCodec{})
+		RegisterJSONDecodeOptions[strictTestConfig](&JSONDecodeOptions{DisallowUnknownFields: true})
+		defer func() {
+			RegisterJSONCodec[strictTestConfig](nil)
+			RegisterJSONDecodeOptions[strictTestConfig](nil)
+		}()
+
+		var js JSON[strictTestConfig]
+		err := js.Scan(`{"title":"a","items":[{"name":"x","size":1}]}`)
+		assert.ErrorIs(t, err, ErrJSONUnknownField)
+		var decodeErr *JSONDecodeError
+		if assert.ErrorAs(t, err, &decodeErr) {
+			assert.Equal(t, "items[0].size", decodeErr.Path)
+		}
+		assert.EqualError(t, js.Scan(`{"title":1}`), "strict codec: the document has an unexpected key")
+	})
+}
@@ -0,0 +1,125 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+)
+
+// LazyJSON field keeps the scanned document and decodes it on the first Get.
+// The untouched document is written back as is by Value and MarshalJSON,
+// so the values which are only passed through are never decoded.
+// Copies of the value share the decoded data.
+type LazyJSON[T any] struct {
+	state *lazyJSONState[T]
+}
+
+type lazyJSONState[T any] struct {
+	mu      sync.Mutex
+	raw     []byte // nil if the data is set directly
+	decoded bool
+	data    T
+	err     error
+}
+
+// NewLazyJSON creates the LazyJSON object with decoded data
+func NewLazyJSON[T any](data T) LazyJSON[T] {
+	var obj LazyJSON[T]
+	obj.Set(data)
+	return obj
+}
+
+// Get returns the decoded data, the document is decoded once
+// and the result is cached, it is safe for concurrent use
+func (f *LazyJSON[T]) Get() (T, error) {
+	if f.state == nil {
+		return *new(T), nil
+	}
+	s := f.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.decoded {
+		s.err = unmarshalJSONData[T](s.raw, &s.data)
+		s.decoded = true
+	}
+	return s.data, s.err
+}
+
+// Set the data, the scanned document is discarded
+func (f *LazyJSON[T]) Set(data T) {
+	f.state = &lazyJSONState[T]{decoded: true, data: data}
+}
+
+// Raw returns the scanned document or nil if the data is set directly
+func (f *LazyJSON[T]) Raw() json.RawMessage {
+	if f.state == nil {
+		return nil
+	}
+	return f.state.raw
+}
+
+// IsDecoded returns true if the data was decoded or set directly
+func (f *LazyJSON[T]) IsDecoded() bool {
+	if f.state == nil {
+		return true
+	}
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.decoded
+}
+
+// Value implements the driver.Valuer interface, json field interface
+func (f LazyJSON[T]) Value() (driver.Value, error) {
+	v, err := f.MarshalJSON()
+	if err == nil && v != nil {
+		return string(v), nil
+	}
+	return nil, err
+}
+
+// Scan implements the sql.Scanner interface, the document is decoded on the first Get
+func (f *LazyJSON[T]) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		f.setRaw([]byte(v))
+	case []byte:
+		f.setRaw(bytes.Clone(v))
+	case json.RawMessage:
+		f.setRaw(bytes.Clone(v))
+	case nil:
+		return ErrNullValueNotAllowed
+	default:
+		return ErrInvalidScan
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler, the untouched document is returned as is
+func (f LazyJSON[T]) MarshalJSON() ([]byte, error) {
+	if f.state == nil {
+		return jsonCodecOf[T]().Marshal(*new(T))
+	}
+	s := f.state
+	s.mu.Lock()
+	raw, decoded, data := s.raw, s.decoded && s.err == nil, s.data
+	s.mu.Unlock()
+	if !decoded {
+		return raw, nil
+	}
+	return jsonCodecOf[T]().Marshal(data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller, the document is decoded on the first Get
+func (f *LazyJSON[T]) UnmarshalJSON(data []byte) error {
+	f.setRaw(bytes.Clone(data))
+	return nil
+}
+
+func (f *LazyJSON[T]) setRaw(raw []byte) {
+	if raw = bytes.TrimSpace(raw); len(raw) == 0 {
+		f.Set(*new(T))
+		return
+	}
+	f.state = &lazyJSONState[T]{raw: raw}
+}
@@ -0,0 +1,124 @@
+package gosql
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lazyTestItem struct {
+	Name string `json:"name"`
+}
+
+func TestLazyJSON(t *testing.T) {
+	codec := newCountingJSONCodec()
+	RegisterJSONCodec[lazyTestItem](codec)
+	defer RegisterJSONCodec[lazyTestItem](nil)
+
+	t.Run("pass_through", func(t *testing.T) {
+		data := []byte(`{ "name": "a",  "extra": 1 }`)
+		var js LazyJSON[lazyTestItem]
+		assert.NoError(t, js.Scan(data))
+		data[3] = 'X' // the driver reuses the buffer
+
+		v, err := js.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{ "name": "a",  "extra": 1 }`, v)
+		assert.Equal(t, json.RawMessage(`{ "name": "a",  "extra": 1 }`), js.Raw())
+
+		res, err := json.Marshal(struct {
+			Item LazyJSON[lazyTestItem] `json:"item"`
+		}{Item: js})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"item":{"name":"a","extra":1}}`, string(res))
+		assert.False(t, js.IsDecoded())
+		assert.Equal(t, 0, *codec.unmarshal)
+		assert.Equal(t, 0, *codec.marshal)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		*codec.unmarshal = 0
+		var js LazyJSON[lazyTestItem]
+		assert.NoError(t, js.Scan(`{"name":"b"}`))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				item, err := js.Get()
+				assert.NoError(t, err)
+				assert.Equal(t, "b", item.Name)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, *codec.unmarshal)
+		assert.True(t, js.IsDecoded())
+
+		js.Set(lazyTestItem{Name: "c"})
+		assert.Nil(t, js.Raw())
+		v, err := js.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"c"}`, v)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var doc struct {
+			Items []LazyJSON[lazyTestItem] `json:"items"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(`{"items":[{"name":"a"},{"name":1}]}`), &doc))
+		if assert.Len(t, doc.Items, 2) {
+			item, err := doc.Items[0].Get()
+			assert.NoError(t, err)
+			assert.Equal(t, "a", item.Name)
+
+			_, err = doc.Items[1].Get()
+			assert.Error(t, err)
+			_, err2 := doc.Items[1].Get()
+			assert.Equal(t, err, err2, "the error is cached")
+			data, err := doc.Items[1].MarshalJSON()
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":1}`, string(data), "the invalid document is returned as is")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var js LazyJSON[lazyTestItem]
+		item, err := js.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, lazyTestItem{}, item)
+		v, err := js.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":""}`, v)
+
+		assert.NoError(t, js.Scan(" "))
+		assert.True(t, js.IsDecoded())
+		assert.ErrorIs(t, js.Scan(nil), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, js.Scan(1), ErrInvalidScan)
+
+		js = NewLazyJSON(lazyTestItem{Name: "d"})
+		item, err = js.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, "d", item.Name)
+	})
+}
+
+func BenchmarkLazyJSONScanValue(b *testing.B) {
+	data := []byte(`{"name":"a","tags":["x","y","z"],"limits":{"a":1,"b":2,"c":3},"description":"some text"}`)
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var js JSON[map[string]any]
+			_ = js.Scan(data)
+			_, _ = js.Value()
+		}
+	})
+	b.Run("lazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var js LazyJSON[map[string]any]
+			_ = js.Scan(data)
+			_, _ = js.Value()
+		}
+	})
+}
@@ -0,0 +1,502 @@
+package gosql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchOperation is the single operation of the JSON Patch (RFC 6902)
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch is the list of operations (RFC 6902) like
+// `[{"op":"replace","path":"/a/b","value":1},{"op":"remove","path":"/c/0"}]`.
+// Supported operations: add, remove, replace, move, copy and test.
+type JSONPatch []JSONPatchOperation
+
+// Apply returns the document with applied operations,
+// the document is not changed if any operation fails
+func (p JSONPatch) Apply(doc []byte) ([]byte, error) {
+	root, err := decodeJSONDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range p {
+		if root, err = op.apply(root); err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s %s): %w", ErrInvalidJSONPatch, i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+// ApplyJSONMergePatch returns the document with applied JSON Merge Patch (RFC 7396):
+// object members of the patch replace members of the document recursively
+// and `null` members remove them
+func ApplyJSONMergePatch(doc, patch []byte) ([]byte, error) {
+	root, err := decodeJSONDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decodeJSONDocument(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeJSONPatch(root, changes))
+}
+
+// DiffJSON returns the JSON Patch which transforms the old document to the new one
+func DiffJSON(oldDoc, newDoc []byte) (JSONPatch, error) {
+	from, err := decodeJSONDocument(oldDoc)
+	if err != nil {
+		return nil, err
+	}
+	to, err := decodeJSONDocument(newDoc)
+	if err != nil {
+		return nil, err
+	}
+	patch := JSONPatch{}
+	return patch, diffJSON(&patch, "", from, to)
+}
+
+// ApplyMergePatch applies JSON Merge Patch (RFC 7396) to the Data,
+// the result is decoded into the Data type and the Data is not changed on error
+func (f *JSON[T]) ApplyMergePatch(patch []byte) error {
+	return f.patchData(func(doc []byte) ([]byte, error) { return ApplyJSONMergePatch(doc, patch) })
+}
+
+// ApplyPatch applies JSON Patch (RFC 6902) to the Data,
+// the result is decoded into the Data type and the Data is not changed on error
+func (f *JSON[T]) ApplyPatch(patch JSONPatch) error {
+	return f.patchData(patch.Apply)
+}
+
+// Diff returns the JSON Patch which transforms the Data to the Data of the target
+func (f JSON[T]) Diff(target JSON[T]) (JSONPatch, error) {
+	return diffJSONMarshalers(f, target)
+}
+
+func (f *JSON[T]) patchData(apply func(doc []byte) ([]byte, error)) error {
+	doc, err := f.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if doc, err = apply(doc); err != nil {
+		return err
+	}
+	var res JSON[T]
+	if err = res.UnmarshalJSON(doc); err != nil {
+		return err
+	}
+	f.Data = res.Data
+	return nil
+}
+
+// ApplyMergePatch applies JSON Merge Patch (RFC 7396) to the Data,
+// the nil Data is patched as `null` and the `null` result sets the Data to nil
+func (f *NullableJSON[T]) ApplyMergePatch(patch []byte) error {
+	return f.patchData(func(doc []byte) ([]byte, error) { return ApplyJSONMergePatch(doc, patch) })
+}
+
+// ApplyPatch applies JSON Patch (RFC 6902) to the Data,
+// the nil Data is patched as `null` and the `null` result sets the Data to nil
+func (f *NullableJSON[T]) ApplyPatch(patch JSONPatch) error {
+	return f.patchData(patch.Apply)
+}
+
+// Diff returns the JSON Patch which transforms the Data to the Data of the target
+func (f NullableJSON[T]) Diff(target NullableJSON[T]) (JSONPatch, error) {
+	return diffJSONMarshalers(f, target)
+}
+
+func (f *NullableJSON[T]) patchData(apply func(doc []byte) ([]byte, error)) error {
+	doc, err := f.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if doc, err = apply(doc); err != nil {
+		return err
+	}
+	var res NullableJSON[T]
+	if !bytes.Equal(doc, []byte("null")) {
+		if err = res.UnmarshalJSON(doc); err != nil {
+			return err
+		}
+	}
+	f.Data = res.Data
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+func diffJSONMarshalers(from, to json.Marshaler) (JSONPatch, error) {
+	oldDoc, err := from.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := to.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return DiffJSON(oldDoc, newDoc)
+}
+
+// decodeJSONDocument decodes the document keeping numbers as json.Number
+func decodeJSONDocument(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w: invalid data after top-level value", ErrInvalidDecodeValue)
+	}
+	return doc, nil
+}
+
+func mergeJSONPatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	doc, ok := target.(map[string]any)
+	if !ok {
+		doc = map[string]any{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(doc, key)
+		} else {
+			doc[key] = mergeJSONPatch(doc[key], value)
+		}
+	}
+	return doc
+}
+
+func (op JSONPatchOperation) apply(root any) (any, error) {
+	path, err := ParseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		value, err := decodeJSONDocument(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return addJSONValue(root, path, value)
+		case "replace":
+			return modifyJSONValue(root, path, func(parent any, key string) (any, error) {
+				return setJSONChild(parent, key, value, false)
+			}, value)
+		}
+		current, err := getJSONValue(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalJSONValues(current, value) {
+			return nil, ErrJSONPatchTestFailed
+		}
+		return root, nil
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("cannot remove the root")
+		}
+		return modifyJSONValue(root, path, removeJSONChild, nil)
+	case "move", "copy":
+		from, err := ParseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getJSONValue(root, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return addJSONValue(root, path, copyJSONValue(value))
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move the value into its child")
+		}
+		if len(from) == 0 {
+			return nil, fmt.Errorf("cannot move the root")
+		}
+		if root, err = modifyJSONValue(root, from, removeJSONChild, nil); err != nil {
+			return nil, err
+		}
+		return addJSONValue(root, path, value)
+	}
+	return nil, fmt.Errorf("unsupported operation")
+}
+
+// ParseJSONPointer splits JSON Pointer (RFC 6901) like `/a/b~1c/0` into unescaped tokens
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// escapeJSONPointerToken escapes `~` and `/` of the JSON Pointer token
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func getJSONValue(node any, path []string) (any, error) {
+	for _, key := range path {
+		var err error
+		if node, err = getJSONChild(node, key); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func getJSONChild(node any, key string) (any, error) {
+	switch val := node.(type) {
+	case map[string]any:
+		if child, ok := val[key]; ok {
+			return child, nil
+		}
+	case []any:
+		if i, err := jsonArrayIndex(key, len(val)-1); err == nil {
+			return val[i], nil
+		}
+	}
+	return nil, fmt.Errorf("path not found at %q", key)
+}
+
+func addJSONValue(root any, path []string, value any) (any, error) {
+	return modifyJSONValue(root, path, func(parent any, key string) (any, error) {
+		return setJSONChild(parent, key, value, true)
+	}, value)
+}
+
+// modifyJSONValue calls fn for the parent of the last token and returns the updated root,
+// the root itself is replaced by the rootValue
+func modifyJSONValue(node any, path []string, fn func(parent any, key string) (any, error), rootValue any) (any, error) {
+	switch len(path) {
+	case 0:
+		return rootValue, nil
+	case 1:
+		return fn(node, path[0])
+	}
+	child, err := getJSONChild(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = modifyJSONValue(child, path[1:], fn, rootValue); err != nil {
+		return nil, err
+	}
+	return setJSONChild(node, path[0], child, false)
+}
+
+// setJSONChild sets the member of the object or the element of the array,
+// insert mode adds new members and inserts array elements
+func setJSONChild(node any, key string, value any, insert bool) (any, error) {
+	switch val := node.(type) {
+	case map[string]any:
+		if _, ok := val[key]; !ok && !insert {
+			return nil, fmt.Errorf("path not found at %q", key)
+		}
+		val[key] = value
+		return val, nil
+	case []any:
+		if !insert {
+			i, err := jsonArrayIndex(key, len(val)-1)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = value
+			return val, nil
+		}
+		if key == "-" {
+			return append(val, value), nil
+		}
+		i, err := jsonArrayIndex(key, len(val))
+		if err != nil {
+			return nil, err
+		}
+		val = append(val, nil)
+		copy(val[i+1:], val[i:])
+		val[i] = value
+		return val, nil
+	}
+	return nil, fmt.Errorf("path not found at %q", key)
+}
+
+func removeJSONChild(node any, key string) (any, error) {
+	switch val := node.(type) {
+	case map[string]any:
+		if _, ok := val[key]; ok {
+			delete(val, key)
+			return val, nil
+		}
+	case []any:
+		if i, err := jsonArrayIndex(key, len(val)-1); err == nil {
+			return append(val[:i], val[i+1:]...), nil
+		}
+	}
+	return nil, fmt.Errorf("path not found at %q", key)
+}
+
+// jsonArrayIndex parses the array index token not greater than max
+func jsonArrayIndex(key string, max int) (int, error) {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || i > max || (len(key) > 1 && key[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", key)
+	}
+	return i, nil
+}
+
+func copyJSONValue(value any) any {
+	switch val := value.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(val))
+		for k, v := range val {
+			res[k] = copyJSONValue(v)
+		}
+		return res
+	case []any:
+		res := make([]any, len(val))
+		for i, v := range val {
+			res[i] = copyJSONValue(v)
+		}
+		return res
+	}
+	return value
+}
+
+// equalJSONValues compares decoded documents, numbers are compared by value
+func equalJSONValues(a, b any) bool {
+	switch va := a.(type) {
+	case json.Number:
+		vb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fa, _, errA := big.ParseFloat(string(va), 10, 256, big.ToNearestEven)
+		fb, _, errB := big.ParseFloat(string(vb), 10, 256, big.ToNearestEven)
+		if errA != nil || errB != nil {
+			return va == vb
+		}
+		return fa.Cmp(fb) == 0
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for k, v := range va {
+			if w, ok := vb[k]; !ok || !equalJSONValues(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		vb, ok := b.([]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !equalJSONValues(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func diffJSON(patch *JSONPatch, path string, from, to any) error {
+	switch vf := from.(type) {
+	case map[string]any:
+		vt, ok := to.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(vf))
+		for key := range vf {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := path + "/" + escapeJSONPointerToken(key)
+			if value, ok := vt[key]; ok {
+				if err := diffJSON(patch, child, vf[key], value); err != nil {
+					return err
+				}
+			} else {
+				*patch = append(*patch, JSONPatchOperation{Op: "remove", Path: child})
+			}
+		}
+		keys = keys[:0]
+		for key := range vt {
+			if _, ok := vf[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := patch.appendValueOp("add", path+"/"+escapeJSONPointerToken(key), vt[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		vt, ok := to.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(vf) && i < len(vt); i++ {
+			if err := diffJSON(patch, path+"/"+strconv.Itoa(i), vf[i], vt[i]); err != nil {
+				return err
+			}
+		}
+		for i := len(vf) - 1; i >= len(vt); i-- {
+			*patch = append(*patch, JSONPatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := len(vf); i < len(vt); i++ {
+			if err := patch.appendValueOp("add", path+"/"+strconv.Itoa(i), vt[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if equalJSONValues(from, to) {
+		return nil
+	}
+	return patch.appendValueOp("replace", path, to)
+}
+
+func (p *JSONPatch) appendValueOp(op, path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, JSONPatchOperation{Op: op, Path: path, Value: data})
+	return nil
+}
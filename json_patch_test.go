@@ -0,0 +1,195 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type patchTestSettings struct {
+	Theme  string         `json:"theme"`
+	Limits map[string]int `json:"limits,omitempty"`
+	Tags   []string       `json:"tags,omitempty"`
+}
+
+func TestApplyJSONMergePatch(t *testing.T) {
+	tests := []struct {
+		doc    string
+		patch  string
+		target string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{`{"n":12345678901234567890}`, `{}`, `{"n":12345678901234567890}`},
+	}
+	for _, test := range tests {
+		res, err := ApplyJSONMergePatch([]byte(test.doc), []byte(test.patch))
+		if assert.NoError(t, err, test.patch) {
+			assert.JSONEq(t, test.target, string(res), test.patch)
+		}
+	}
+	_, err := ApplyJSONMergePatch([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestJSONPatchApply(t *testing.T) {
+	tests := []struct {
+		doc    string
+		patch  string
+		target string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"foo":{"a":[1]}}`, `[{"op":"copy","from":"/foo/a","path":"/bar"},{"op":"add","path":"/bar/-","value":2}]`, `{"foo":{"a":[1]},"bar":[1,2]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"a/b":{"m~n":1}}`, `[{"op":"replace","path":"/a~1b/m~0n","value":2}]`, `{"a/b":{"m~n":2}}`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+	}
+	for _, test := range tests {
+		var patch JSONPatch
+		if !assert.NoError(t, json.Unmarshal([]byte(test.patch), &patch)) {
+			continue
+		}
+		res, err := patch.Apply([]byte(test.doc))
+		if assert.NoError(t, err, test.patch) {
+			assert.JSONEq(t, test.target, string(res), test.patch)
+		}
+	}
+}
+
+func TestJSONPatchApplyErrors(t *testing.T) {
+	tests := []struct {
+		doc   string
+		patch string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`},
+		{`{"foo":[1]}`, `[{"op":"add","path":"/foo/2","value":1}]`},
+		{`{"foo":[1]}`, `[{"op":"add","path":"/foo/01","value":1}]`},
+		{`{"foo":[1]}`, `[{"op":"remove","path":"/foo/1"}]`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"baz","value":1}]`},
+		{`{"foo":"bar"}`, `[{"op":"unknown","path":"/foo"}]`},
+		{`{"foo":{"a":1}}`, `[{"op":"move","from":"/foo","path":"/foo/b"}]`},
+		{`{"foo":"bar"}`, `[{"op":"copy","from":"/bar","path":"/baz"}]`},
+	}
+	for _, test := range tests {
+		var patch JSONPatch
+		if !assert.NoError(t, json.Unmarshal([]byte(test.patch), &patch)) {
+			continue
+		}
+		_, err := patch.Apply([]byte(test.doc))
+		assert.ErrorIs(t, err, ErrInvalidJSONPatch, test.patch)
+	}
+
+	_, err := JSONPatch{{Op: "test", Path: "/foo", Value: json.RawMessage(`"baz"`)}}.Apply([]byte(`{"foo":"bar"}`))
+	assert.ErrorIs(t, err, ErrJSONPatchTestFailed)
+	assert.ErrorIs(t, err, ErrInvalidJSONPatch)
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+	}{
+		{`{"a":1}`, `{"a":1}`},
+		{`{"a":1,"b":{"c":"x"}}`, `{"a":2,"b":{"d":"y"}}`},
+		{`{"list":[1,2,3]}`, `{"list":[1,5]}`},
+		{`{"list":[1]}`, `{"list":[1,{"a":true},null]}`},
+		{`{"a/b":1,"m~n":2}`, `{"a/b":3}`},
+		{`{"a":[1]}`, `{"a":{"b":1}}`},
+		{`[1,2]`, `"text"`},
+		{`{"n":1}`, `{"n":1.0}`},
+	}
+	for _, test := range tests {
+		patch, err := DiffJSON([]byte(test.from), []byte(test.to))
+		if !assert.NoError(t, err, test.to) {
+			continue
+		}
+		res, err := patch.Apply([]byte(test.from))
+		if assert.NoError(t, err, test.to) {
+			assert.JSONEq(t, test.to, string(res), test.to)
+		}
+	}
+
+	patch, err := DiffJSON([]byte(`{"a":1,"b":{"c":"x"},"d":[1,2]}`), []byte(`{"a":2,"b":{"c":"x","e":null},"d":[1]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, JSONPatch{
+		{Op: "replace", Path: "/a", Value: json.RawMessage(`2`)},
+		{Op: "add", Path: "/b/e", Value: json.RawMessage(`null`)},
+		{Op: "remove", Path: "/d/1"},
+	}, patch)
+
+	data, err := json.Marshal(patch)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"replace","path":"/a","value":2},{"op":"add","path":"/b/e","value":null},{"op":"remove","path":"/d/1"}]`, string(data))
+}
+
+func TestJSONPatchMethods(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		js := JSON[patchTestSettings]{Data: patchTestSettings{Theme: "dark", Limits: map[string]int{"a": 1, "b": 2}}}
+		assert.NoError(t, js.ApplyMergePatch([]byte(`{"limits":{"a":null,"c":3},"tags":["x"]}`)))
+		assert.Equal(t, patchTestSettings{Theme: "dark", Limits: map[string]int{"b": 2, "c": 3}, Tags: []string{"x"}}, js.Data)
+
+		assert.NoError(t, js.ApplyPatch(JSONPatch{
+			{Op: "replace", Path: "/theme", Value: json.RawMessage(`"light"`)},
+			{Op: "add", Path: "/tags/0", Value: json.RawMessage(`"y"`)},
+		}))
+		assert.Equal(t, "light", js.Data.Theme)
+		assert.Equal(t, []string{"y", "x"}, js.Data.Tags)
+
+		// The typed re-decode fails and the data is not changed
+		before := js.Data
+		assert.Error(t, js.ApplyMergePatch([]byte(`{"theme":1}`)))
+		assert.Equal(t, before, js.Data)
+		assert.ErrorIs(t, js.ApplyPatch(JSONPatch{{Op: "remove", Path: "/unknown"}}), ErrInvalidJSONPatch)
+		assert.Equal(t, before, js.Data)
+
+		target := JSON[patchTestSettings]{Data: patchTestSettings{Theme: "light", Tags: []string{"y"}}}
+		patch, err := js.Diff(target)
+		assert.NoError(t, err)
+		assert.NoError(t, js.ApplyPatch(patch))
+		assert.Equal(t, target.Data, js.Data)
+	})
+
+	t.Run("nullable", func(t *testing.T) {
+		var js NullableJSON[patchTestSettings]
+		assert.NoError(t, js.ApplyMergePatch([]byte(`{"theme":"dark"}`)))
+		if assert.NotNil(t, js.Data) {
+			assert.Equal(t, "dark", js.Data.Theme)
+		}
+
+		assert.NoError(t, js.ApplyPatch(JSONPatch{{Op: "replace", Path: "", Value: json.RawMessage(`null`)}}))
+		assert.Nil(t, js.Data)
+
+		target := NullableJSON[patchTestSettings]{Data: &patchTestSettings{Theme: "light"}}
+		patch, err := js.Diff(target)
+		assert.NoError(t, err)
+		assert.NoError(t, js.ApplyPatch(patch))
+		assert.Equal(t, target.Data, js.Data)
+
+		assert.NoError(t, js.ApplyMergePatch([]byte(`null`)))
+		assert.Nil(t, js.Data)
+	})
+}
@@ -0,0 +1,633 @@
+package gosql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONPathSegment is the object key or the array index of the path,
+// numeric JSON Pointer tokens are both the key of objects and the index of arrays
+type JSONPathSegment struct {
+	Key   string
+	Index int // -1 if the segment is not the array index
+}
+
+// IsIndex returns true if the segment is the array index only like `[2]`
+func (s JSONPathSegment) IsIndex() bool {
+	return s.Key == "" && s.Index >= 0
+}
+
+// JSONPath is the parsed path of the value inside the JSON document
+type JSONPath []JSONPathSegment
+
+// ParseJSONPath parses JSONPath like `$.a.b[2].c`, `a['b c'][0]` or JSON Pointer like `/a/b/2/c`,
+// wildcards, filters and recursive descent are not supported
+func ParseJSONPath(path string) (JSONPath, error) {
+	if path == "" || path[0] == '/' {
+		tokens, err := ParseJSONPointer(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidJSONPath, err)
+		}
+		res := make(JSONPath, 0, len(tokens))
+		for _, token := range tokens {
+			seg := JSONPathSegment{Key: token, Index: -1}
+			if i, err := jsonArrayIndex(token, math.MaxInt32); err == nil {
+				seg.Index = i
+			}
+			res = append(res, seg)
+		}
+		return res, nil
+	}
+	s := strings.TrimPrefix(path, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' && s == path {
+		s = "." + s
+	}
+	var res JSONPath
+	for s != "" {
+		if s[0] == '.' {
+			end := strings.IndexAny(s[1:], ".[") + 1
+			if end == 0 {
+				end = len(s)
+			}
+			name := s[1:end]
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("%w %q: unsupported syntax", ErrInvalidJSONPath, path)
+			}
+			res, s = append(res, JSONPathSegment{Key: name, Index: -1}), s[end:]
+			continue
+		}
+		if s[0] != '[' {
+			return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidJSONPath, path, s[0])
+		}
+		seg, n, err := parseJSONPathBracket(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidJSONPath, path, err)
+		}
+		res, s = append(res, seg), s[n:]
+	}
+	return res, nil
+}
+
+// parseJSONPathBracket parses `[2]`, `['key']` or `["key"]` and returns the length of the segment
+func parseJSONPathBracket(s string) (JSONPathSegment, int, error) {
+	if len(s) > 1 && (s[1] == '\'' || s[1] == '"') {
+		var key strings.Builder
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				if i++; i < len(s) {
+					key.WriteByte(s[i])
+				}
+			case s[1]:
+				if i+1 >= len(s) || s[i+1] != ']' {
+					return JSONPathSegment{}, 0, fmt.Errorf("expected ]")
+				}
+				return JSONPathSegment{Key: key.String(), Index: -1}, i + 2, nil
+			default:
+				key.WriteByte(s[i])
+			}
+		}
+		return JSONPathSegment{}, 0, fmt.Errorf("unterminated key")
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return JSONPathSegment{}, 0, fmt.Errorf("expected ]")
+	}
+	i, err := jsonArrayIndex(s[1:end], math.MaxInt32)
+	if err != nil {
+		return JSONPathSegment{}, 0, err
+	}
+	return JSONPathSegment{Index: i}, end + 1, nil
+}
+
+// String returns the path in JSONPath syntax like `$.a['b c'][2]`
+func (p JSONPath) String() string {
+	var buf strings.Builder
+	buf.WriteByte('$')
+	for _, seg := range p {
+		switch {
+		case seg.Index >= 0 && (seg.Key == "" || seg.Key == strconv.Itoa(seg.Index)):
+			buf.WriteString("[" + strconv.Itoa(seg.Index) + "]")
+		case isJSONPathIdentifier(seg.Key):
+			buf.WriteString("." + seg.Key)
+		default:
+			buf.WriteString("['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(seg.Key) + "']")
+		}
+	}
+	return buf.String()
+}
+
+// Pointer returns the path in JSON Pointer syntax like `/a/b c/2`
+func (p JSONPath) Pointer() string {
+	var buf strings.Builder
+	for _, seg := range p {
+		buf.WriteByte('/')
+		if seg.IsIndex() {
+			buf.WriteString(strconv.Itoa(seg.Index))
+		} else {
+			buf.WriteString(escapeJSONPointerToken(seg.Key))
+		}
+	}
+	return buf.String()
+}
+
+func isJSONPathIdentifier(key string) bool {
+	for i, c := range key {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return key != ""
+}
+
+// JSONPathError is the error of the path access with the path of the failed segment
+type JSONPathError struct {
+	Path string
+	Err  error
+}
+
+func (e *JSONPathError) Error() string {
+	return "json path " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *JSONPathError) Unwrap() error { return e.Err }
+
+// Get returns the value of the Data by the path like `$.a.b[2]` or `/a/b/2`
+func (f *JSON[T]) Get(path string) (any, error) {
+	return getJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// Has returns true if the value of the path exists
+func (f *JSON[T]) Has(path string) bool {
+	_, err := getJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+	return err == nil
+}
+
+// Set the value of the Data by the path, missing objects are created
+// and the index equal to the array length appends the element
+func (f *JSON[T]) Set(path string, value any) error {
+	return setJSONPath(reflect.ValueOf(&f.Data).Elem(), path, value)
+}
+
+// Delete the object key or the array element by the path
+func (f *JSON[T]) Delete(path string) error {
+	return deleteJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetString returns the string value by the path
+func (f *JSON[T]) GetString(path string) (string, error) {
+	return getJSONPathString(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetInt returns the integer value by the path
+func (f *JSON[T]) GetInt(path string) (int, error) {
+	return getJSONPathInt(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetInt64 returns the integer value by the path
+func (f *JSON[T]) GetInt64(path string) (int64, error) {
+	return getJSONPathInt64(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetFloat64 returns the number value by the path
+func (f *JSON[T]) GetFloat64(path string) (float64, error) {
+	return getJSONPathFloat64(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetBool returns the boolean value by the path
+func (f *JSON[T]) GetBool(path string) (bool, error) {
+	return getJSONPathBool(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// Get returns the value of the Data by the path like `$.a.b[2]` or `/a/b/2`
+func (f *NullableJSON[T]) Get(path string) (any, error) {
+	return getJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// Has returns true if the value of the path exists
+func (f *NullableJSON[T]) Has(path string) bool {
+	_, err := getJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+	return err == nil
+}
+
+// Set the value of the Data by the path, the nil Data and missing objects are created
+// and the index equal to the array length appends the element
+func (f *NullableJSON[T]) Set(path string, value any) error {
+	return setJSONPath(reflect.ValueOf(&f.Data).Elem(), path, value)
+}
+
+// Delete the object key or the array element by the path
+func (f *NullableJSON[T]) Delete(path string) error {
+	return deleteJSONPath(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetString returns the string value by the path
+func (f *NullableJSON[T]) GetString(path string) (string, error) {
+	return getJSONPathString(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetInt returns the integer value by the path
+func (f *NullableJSON[T]) GetInt(path string) (int, error) {
+	return getJSONPathInt(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetInt64 returns the integer value by the path
+func (f *NullableJSON[T]) GetInt64(path string) (int64, error) {
+	return getJSONPathInt64(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetFloat64 returns the number value by the path
+func (f *NullableJSON[T]) GetFloat64(path string) (float64, error) {
+	return getJSONPathFloat64(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+// GetBool returns the boolean value by the path
+func (f *NullableJSON[T]) GetBool(path string) (bool, error) {
+	return getJSONPathBool(reflect.ValueOf(&f.Data).Elem(), path)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+func getJSONPath(root reflect.Value, path string) (any, error) {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := p.lookup(root)
+	if err != nil {
+		return nil, err
+	}
+	return node.Interface(), nil
+}
+
+func setJSONPath(root reflect.Value, path string, value any) error {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		return err
+	}
+	node, err := p.set(root.Type(), root, 0, value)
+	if err != nil {
+		return err
+	}
+	root.Set(node)
+	return nil
+}
+
+func deleteJSONPath(root reflect.Value, path string) error {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		return err
+	}
+	if len(p) == 0 {
+		root.Set(reflect.Zero(root.Type()))
+		return nil
+	}
+	node, err := p.delete(root, 0)
+	if err != nil {
+		return err
+	}
+	root.Set(node)
+	return nil
+}
+
+func getJSONPathString(root reflect.Value, path string) (string, error) {
+	val, p, err := getJSONPathValue(root, path)
+	if err != nil {
+		return "", err
+	}
+	if val.Kind() != reflect.String || val.Type() == jsonNumberType {
+		return "", p.typeError(len(p)-1, "string", val)
+	}
+	return val.String(), nil
+}
+
+func getJSONPathInt(root reflect.Value, path string) (int, error) {
+	val, p, err := getJSONPathValue(root, path)
+	if err != nil {
+		return 0, err
+	}
+	v, ok := jsonValueInt64(val)
+	if !ok || int64(int(v)) != v {
+		return 0, p.typeError(len(p)-1, "integer", val)
+	}
+	return int(v), nil
+}
+
+func getJSONPathInt64(root reflect.Value, path string) (int64, error) {
+	val, p, err := getJSONPathValue(root, path)
+	if err != nil {
+		return 0, err
+	}
+	v, ok := jsonValueInt64(val)
+	if !ok {
+		return 0, p.typeError(len(p)-1, "integer", val)
+	}
+	return v, nil
+}
+
+// jsonValueInt64 returns the integer of numbers without the fractional part
+func jsonValueInt64(val reflect.Value) (int64, bool) {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v := val.Uint()
+		return int64(v), v <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		v := val.Float()
+		return int64(v), v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64
+	case reflect.String:
+		if val.Type() == jsonNumberType {
+			v, err := strconv.ParseInt(val.String(), 10, 64)
+			return v, err == nil
+		}
+	}
+	return 0, false
+}
+
+func getJSONPathFloat64(root reflect.Value, path string) (float64, error) {
+	val, p, err := getJSONPathValue(root, path)
+	if err != nil {
+		return 0, err
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	case reflect.String:
+		if val.Type() == jsonNumberType {
+			if v, err := strconv.ParseFloat(val.String(), 64); err == nil {
+				return v, nil
+			}
+		}
+	}
+	return 0, p.typeError(len(p)-1, "number", val)
+}
+
+func getJSONPathBool(root reflect.Value, path string) (bool, error) {
+	val, p, err := getJSONPathValue(root, path)
+	if err != nil {
+		return false, err
+	}
+	if val.Kind() != reflect.Bool {
+		return false, p.typeError(len(p)-1, "boolean", val)
+	}
+	return val.Bool(), nil
+}
+
+// getJSONPathValue returns the value of the path without interfaces and pointers
+func getJSONPathValue(root reflect.Value, path string) (reflect.Value, JSONPath, error) {
+	p, err := ParseJSONPath(path)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	node, err := p.lookup(root)
+	return indirectJSONValue(node), p, err
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// indirectJSONValue returns the value of interfaces and pointers, nil is the invalid value
+func indirectJSONValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// jsonTypeName returns the JSON name of the value type for error messages
+func jsonTypeName(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return "null"
+	case reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		if v.Type() == jsonNumberType {
+			return "number"
+		}
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return v.Type().String()
+}
+
+// error returns the error of the segment i
+func (p JSONPath) error(i int, err error) error {
+	return &JSONPathError{Path: p[:i+1].String(), Err: err}
+}
+
+func (p JSONPath) typeError(i int, expected string, v reflect.Value) error {
+	return p.error(i, fmt.Errorf("%w: expected %s, got %s", ErrJSONPathType, expected, jsonTypeName(v)))
+}
+
+func (p JSONPath) lookup(node reflect.Value) (reflect.Value, error) {
+	for i, seg := range p {
+		node = indirectJSONValue(node)
+		switch node.Kind() {
+		case reflect.Map:
+			if node.Type().Key().Kind() != reflect.String || seg.IsIndex() {
+				return reflect.Value{}, p.typeError(i, "array", node)
+			}
+			child := node.MapIndex(reflect.ValueOf(seg.Key).Convert(node.Type().Key()))
+			if !child.IsValid() {
+				return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+			}
+			node = child
+		case reflect.Slice, reflect.Array:
+			if seg.Index < 0 {
+				return reflect.Value{}, p.typeError(i, "object", node)
+			}
+			if seg.Index >= node.Len() {
+				return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+			}
+			node = node.Index(seg.Index)
+		case reflect.Invalid:
+			return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+		default:
+			return reflect.Value{}, p.typeError(i, "object or array", node)
+		}
+	}
+	if (node.Kind() == reflect.Pointer || node.Kind() == reflect.Interface) && node.IsNil() {
+		return reflect.Zero(reflect.TypeOf((*any)(nil)).Elem()), nil
+	}
+	return node, nil
+}
+
+// set returns the node of the type t with the value of the path from the segment i,
+// the invalid node is created
+func (p JSONPath) set(t reflect.Type, node reflect.Value, i int, value any) (reflect.Value, error) {
+	if i == len(p) {
+		return p.convert(t, value)
+	}
+	seg := p[i]
+	switch t.Kind() {
+	case reflect.Interface:
+		if node.IsValid() && !node.IsNil() {
+			elem := node.Elem()
+			return p.set(elem.Type(), elem, i, value)
+		}
+		if t.NumMethod() > 0 {
+			return reflect.Value{}, p.typeError(i, "object or array", node)
+		}
+		if seg.IsIndex() {
+			return p.set(reflect.TypeOf([]any(nil)), reflect.Value{}, i, value)
+		}
+		return p.set(reflect.TypeOf(map[string]any(nil)), reflect.Value{}, i, value)
+	case reflect.Pointer:
+		ptr := node
+		if !ptr.IsValid() || ptr.IsNil() {
+			ptr = reflect.New(t.Elem())
+		}
+		elem, err := p.set(t.Elem(), ptr.Elem(), i, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || seg.IsIndex() {
+			return reflect.Value{}, p.typeError(i, "array", node)
+		}
+		m := node
+		if !m.IsValid() || m.IsNil() {
+			m = reflect.MakeMap(t)
+		}
+		key := reflect.ValueOf(seg.Key).Convert(t.Key())
+		child, err := p.set(t.Elem(), m.MapIndex(key), i+1, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		m.SetMapIndex(key, child)
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		if seg.Index < 0 && (seg.Key != "-" || t.Kind() == reflect.Array) {
+			return reflect.Value{}, p.typeError(i, "object", node)
+		}
+		arr := reflect.New(t).Elem()
+		if node.IsValid() {
+			arr.Set(node)
+		}
+		idx := seg.Index
+		if seg.Key == "-" {
+			idx = arr.Len()
+		}
+		if idx < arr.Len() {
+			child, err := p.set(t.Elem(), arr.Index(idx), i+1, value)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			arr.Index(idx).Set(child)
+			return arr, nil
+		}
+		if idx > arr.Len() || t.Kind() == reflect.Array {
+			return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+		}
+		child, err := p.set(t.Elem(), reflect.Value{}, i+1, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.Append(arr, child), nil
+	}
+	return reflect.Value{}, p.typeError(i, "object or array", node)
+}
+
+// convert returns the value assignable to the type t, numbers are converted
+func (p JSONPath) convert(t reflect.Type, value any) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+		return reflect.Zero(t), nil
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case isJSONNumberKind(v.Kind()) && isJSONNumberKind(t.Kind()):
+		return v.Convert(t), nil
+	case t.Kind() == reflect.Pointer && v.Type().AssignableTo(t.Elem()):
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr, nil
+	}
+	return reflect.Value{}, &JSONPathError{Path: p.String(),
+		Err: fmt.Errorf("%w: expected %s, got %s", ErrJSONPathType, t, v.Type())}
+}
+
+func isJSONNumberKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// delete returns the node without the value of the path from the segment i
+func (p JSONPath) delete(node reflect.Value, i int) (reflect.Value, error) {
+	seg, last := p[i], i == len(p)-1
+	switch node.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if node.IsNil() {
+			return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+		}
+		elem, err := p.delete(node.Elem(), i)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if node.Kind() == reflect.Interface {
+			return elem, nil
+		}
+		node.Elem().Set(elem)
+		return node, nil
+	case reflect.Map:
+		if node.Type().Key().Kind() != reflect.String || seg.IsIndex() {
+			return reflect.Value{}, p.typeError(i, "array", node)
+		}
+		key := reflect.ValueOf(seg.Key).Convert(node.Type().Key())
+		child := node.MapIndex(key)
+		if !child.IsValid() {
+			return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+		}
+		if last {
+			node.SetMapIndex(key, reflect.Value{})
+			return node, nil
+		}
+		child, err := p.delete(child, i+1)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		node.SetMapIndex(key, child)
+		return node, nil
+	case reflect.Slice, reflect.Array:
+		if seg.Index < 0 || (last && node.Kind() == reflect.Array) {
+			return reflect.Value{}, p.typeError(i, "object", node)
+		}
+		if seg.Index >= node.Len() {
+			return reflect.Value{}, p.error(i, ErrJSONPathNotFound)
+		}
+		if last {
+			return reflect.AppendSlice(node.Slice(0, seg.Index), node.Slice(seg.Index+1, node.Len())), nil
+		}
+		arr := reflect.New(node.Type()).Elem()
+		arr.Set(node)
+		child, err := p.delete(arr.Index(seg.Index), i+1)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		arr.Index(seg.Index).Set(child)
+		return arr, nil
+	}
+	return reflect.Value{}, p.typeError(i, "object or array", indirectJSONValue(node))
+}
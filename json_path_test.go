@@ -0,0 +1,180 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		target  JSONPath
+		str     string
+		pointer string
+	}{
+		{"", JSONPath{}, "$", ""},
+		{"$", nil, "$", ""},
+		{"$.a.b[2].c", JSONPath{{"a", -1}, {"b", -1}, {"", 2}, {"c", -1}}, "$.a.b[2].c", "/a/b/2/c"},
+		{"a.b[0]", JSONPath{{"a", -1}, {"b", -1}, {"", 0}}, "$.a.b[0]", "/a/b/0"},
+		{"[1].a", JSONPath{{"", 1}, {"a", -1}}, "$[1].a", "/1/a"},
+		{`$['a b']["c'd"]['e\'f']`, JSONPath{{"a b", -1}, {"c'd", -1}, {"e'f", -1}}, `$['a b']['c\'d']['e\'f']`, "/a b/c'd/e'f"},
+		{"/a/2/b~1c/m~0n/", JSONPath{{"a", -1}, {"2", 2}, {"b/c", -1}, {"m~n", -1}, {"", -1}}, "$.a[2]['b/c']['m~n']['']", "/a/2/b~1c/m~0n/"},
+		{"/01", JSONPath{{"01", -1}}, "$['01']", "/01"},
+	}
+	for _, test := range tests {
+		path, err := ParseJSONPath(test.path)
+		if assert.NoError(t, err, test.path) {
+			assert.Equal(t, test.target, path, test.path)
+			assert.Equal(t, test.str, path.String(), test.path)
+			assert.Equal(t, test.pointer, path.Pointer(), test.path)
+		}
+	}
+
+	for _, path := range []string{"$.", "$..a", "$.a[*]", "$.a[-1]", "$.a[x]", "$a", "a[1", "a['b'", "a['b'x]"} {
+		_, err := ParseJSONPath(path)
+		assert.ErrorIs(t, err, ErrInvalidJSONPath, path)
+	}
+}
+
+func TestJSONPathAccessors(t *testing.T) {
+	var js JSON[map[string]any]
+	assert.NoError(t, js.Scan(`{"a":{"b":[{"c":"x"},{"c":2.5,"d":3}]},"flag":true,"n":12}`))
+
+	t.Run("get", func(t *testing.T) {
+		v, err := js.Get("$.a.b[0].c")
+		assert.NoError(t, err)
+		assert.Equal(t, "x", v)
+		v, err = js.Get("/a/b/1/d")
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, v)
+		assert.True(t, js.Has("a.b[1]"))
+		assert.False(t, js.Has("a.b[2]"))
+		assert.False(t, js.Has("a.x.y"))
+
+		_, err = js.Get("a.b[2].c")
+		assert.ErrorIs(t, err, ErrJSONPathNotFound)
+		assert.EqualError(t, err, "json path $.a.b[2]: value not found")
+
+		_, err = js.Get("a.b.c")
+		assert.ErrorIs(t, err, ErrJSONPathType)
+		assert.EqualError(t, err, "json path $.a.b.c: unexpected value type: expected object, got array")
+
+		_, err = js.Get("flag[0]")
+		assert.EqualError(t, err, "json path $.flag[0]: unexpected value type: expected object or array, got boolean")
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		s, err := js.GetString("a.b[0].c")
+		assert.NoError(t, err)
+		assert.Equal(t, "x", s)
+		i, err := js.GetInt("a.b[1].d")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, i)
+		i64, err := js.GetInt64("n")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), i64)
+		f, err := js.GetFloat64("a.b[1].c")
+		assert.NoError(t, err)
+		assert.Equal(t, 2.5, f)
+		b, err := js.GetBool("flag")
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		_, err = js.GetString("a.b[1].c")
+		assert.EqualError(t, err, "json path $.a.b[1].c: unexpected value type: expected string, got number")
+		_, err = js.GetInt("a.b[1].c")
+		assert.EqualError(t, err, "json path $.a.b[1].c: unexpected value type: expected integer, got number")
+		_, err = js.GetBool("a")
+		assert.EqualError(t, err, "json path $.a: unexpected value type: expected boolean, got object")
+		_, err = js.GetFloat64("a.b[5]")
+		assert.ErrorIs(t, err, ErrJSONPathNotFound)
+		var pathErr *JSONPathError
+		if assert.ErrorAs(t, err, &pathErr) {
+			assert.Equal(t, "$.a.b[5]", pathErr.Path)
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		js := JSON[map[string]any]{}
+		assert.NoError(t, js.Set("a.b[0].c", "x"))
+		assert.NoError(t, js.Set("/a/b/0/d", 1))
+		assert.NoError(t, js.Set("a.b[1]", true))
+		assert.NoError(t, js.Set("/a/b/-", nil))
+		assert.NoError(t, js.Set("$['x y']", []int{1}))
+		assert.Equal(t, map[string]any{
+			"a":   map[string]any{"b": []any{map[string]any{"c": "x", "d": 1}, true, nil}},
+			"x y": []int{1},
+		}, js.Data)
+
+		assert.ErrorIs(t, js.Set("a.b[5]", 1), ErrJSONPathNotFound)
+		assert.ErrorIs(t, js.Set("a.b.c", 1), ErrJSONPathType)
+		assert.EqualError(t, js.Set("$['x y'][0]", "s"), "json path $['x y'][0]: unexpected value type: expected int, got string")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		js := JSON[map[string]any]{}
+		assert.NoError(t, js.Scan(`{"a":{"b":[1,2,3]},"c":1}`))
+		assert.NoError(t, js.Delete("a.b[1]"))
+		assert.NoError(t, js.Delete("/c"))
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": []any{1.0, 3.0}}}, js.Data)
+		assert.ErrorIs(t, js.Delete("c"), ErrJSONPathNotFound)
+		assert.ErrorIs(t, js.Delete("a.b[2]"), ErrJSONPathNotFound)
+		assert.NoError(t, js.Delete(""))
+		assert.Nil(t, js.Data)
+	})
+}
+
+func TestJSONPathTypedData(t *testing.T) {
+	js := JSON[map[string][]int]{Data: map[string][]int{"a": {1, 2}}}
+	v, err := js.GetInt("a[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.NoError(t, js.Set("a[2]", int64(3)))
+	assert.NoError(t, js.Set("b[0]", 4.0))
+	assert.Equal(t, map[string][]int{"a": {1, 2, 3}, "b": {4}}, js.Data)
+	assert.ErrorIs(t, js.Set("a", "x"), ErrJSONPathType)
+
+	arr := JSON[[]json.Number]{Data: []json.Number{"12", "1.5"}}
+	i, err := arr.GetInt64("[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), i)
+	f, err := arr.GetFloat64("[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+	_, err = arr.GetString("[0]")
+	assert.ErrorIs(t, err, ErrJSONPathType)
+
+	st := JSON[patchTestSettings]{}
+	_, err = st.Get("theme")
+	assert.EqualError(t, err, "json path $.theme: unexpected value type: expected object or array, got gosql.patchTestSettings")
+}
+
+func TestNullableJSONPathAccessors(t *testing.T) {
+	var js NullableJSON[map[string]any]
+	assert.False(t, js.Has("a"))
+	_, err := js.Get("a")
+	assert.ErrorIs(t, err, ErrJSONPathNotFound)
+	v, err := js.Get("")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	assert.NoError(t, js.Set("a.b", 1))
+	if assert.NotNil(t, js.Data) {
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": 1}}, *js.Data)
+	}
+	i, err := js.GetInt("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	assert.NoError(t, js.Delete("a.b"))
+	assert.Equal(t, map[string]any{"a": map[string]any{}}, *js.Data)
+	assert.NoError(t, js.Delete(""))
+	assert.Nil(t, js.Data)
+
+	assert.NoError(t, js.Set("", map[string]any{"x": "y"}))
+	s, err := js.GetString("x")
+	assert.NoError(t, err)
+	assert.Equal(t, "y", s)
+}
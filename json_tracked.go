@@ -0,0 +1,159 @@
+package gosql
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// TrackedJSON is the JSON field which remembers the scanned document
+// to report changes of the Data since it was loaded from the database
+type TrackedJSON[T any] struct {
+	JSON[T]
+	original []byte
+}
+
+// Scan implements the sql.Scanner interface and remembers the scanned document
+func (f *TrackedJSON[T]) Scan(value any) error {
+	f.original = nil
+	if err := f.JSON.Scan(value); err != nil {
+		return err
+	}
+	f.original = scannedJSON(value)
+	return nil
+}
+
+// Original returns the scanned document or nil if the value was not scanned
+func (f TrackedJSON[T]) Original() json.RawMessage {
+	return f.original
+}
+
+// Changed returns true if the Data differs from the scanned document
+// or the value was not scanned
+func (f TrackedJSON[T]) Changed() bool {
+	return trackedJSONChanged(f.original, f.JSON)
+}
+
+// Patch returns the JSON Patch which transforms the scanned document to the Data,
+// the not scanned value is replaced entirely
+func (f TrackedJSON[T]) Patch() (JSONPatch, error) {
+	return trackedJSONPatch(f.original, f.JSON)
+}
+
+// ChangedPaths returns JSON Pointers of changed values like `/limits/max`
+func (f TrackedJSON[T]) ChangedPaths() ([]string, error) {
+	return trackedJSONPaths(f.original, f.JSON)
+}
+
+// ResetChanges marks the current Data as the stored document, use it after the value is saved
+func (f *TrackedJSON[T]) ResetChanges() error {
+	data, err := f.JSON.MarshalJSON()
+	if err == nil {
+		f.original = data
+	}
+	return err
+}
+
+// NullableTrackedJSON is the NullableJSON field which remembers the scanned document
+// to report changes of the Data since it was loaded from the database
+type NullableTrackedJSON[T any] struct {
+	NullableJSON[T]
+	original []byte
+}
+
+// Scan implements the sql.Scanner interface and remembers the scanned document,
+// NULL is remembered as `null`
+func (f *NullableTrackedJSON[T]) Scan(value any) error {
+	f.original = nil
+	if err := f.NullableJSON.Scan(value); err != nil {
+		return err
+	}
+	if value == nil {
+		f.original = []byte("null")
+	} else {
+		f.original = scannedJSON(value)
+	}
+	return nil
+}
+
+// Original returns the scanned document or nil if the value was not scanned
+func (f NullableTrackedJSON[T]) Original() json.RawMessage {
+	return f.original
+}
+
+// Changed returns true if the Data differs from the scanned document
+// or the value was not scanned
+func (f NullableTrackedJSON[T]) Changed() bool {
+	return trackedJSONChanged(f.original, f.NullableJSON)
+}
+
+// Patch returns the JSON Patch which transforms the scanned document to the Data,
+// the not scanned value is replaced entirely
+func (f NullableTrackedJSON[T]) Patch() (JSONPatch, error) {
+	return trackedJSONPatch(f.original, f.NullableJSON)
+}
+
+// ChangedPaths returns JSON Pointers of changed values like `/limits/max`
+func (f NullableTrackedJSON[T]) ChangedPaths() ([]string, error) {
+	return trackedJSONPaths(f.original, f.NullableJSON)
+}
+
+// ResetChanges marks the current Data as the stored document, use it after the value is saved
+func (f *NullableTrackedJSON[T]) ResetChanges() error {
+	data, err := f.NullableJSON.MarshalJSON()
+	if err == nil {
+		f.original = data
+	}
+	return err
+}
+
+// scannedJSON returns the copy of the scanned document,
+// the driver can reuse the buffer after the scan
+func scannedJSON(value any) []byte {
+	switch v := value.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return bytes.Clone(v)
+	case json.RawMessage:
+		return bytes.Clone(v)
+	}
+	return nil
+}
+
+func trackedJSONChanged(original []byte, current json.Marshaler) bool {
+	if original == nil {
+		return true
+	}
+	data, err := current.MarshalJSON()
+	if err != nil {
+		return true
+	}
+	if bytes.Equal(original, data) {
+		return false
+	}
+	patch, err := DiffJSON(original, data)
+	return err != nil || len(patch) > 0
+}
+
+func trackedJSONPatch(original []byte, current json.Marshaler) (JSONPatch, error) {
+	data, err := current.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return JSONPatch{{Op: "replace", Path: "", Value: data}}, nil
+	}
+	return DiffJSON(original, data)
+}
+
+func trackedJSONPaths(original []byte, current json.Marshaler) ([]string, error) {
+	patch, err := trackedJSONPatch(original, current)
+	if err != nil || len(patch) == 0 {
+		return nil, err
+	}
+	paths := make([]string, 0, len(patch))
+	for _, op := range patch {
+		paths = append(paths, op.Path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,70 @@
+package gosql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackedJSON(t *testing.T) {
+	var js TrackedJSON[patchTestSettings]
+	assert.True(t, js.Changed(), "not scanned value")
+	assert.Nil(t, js.Original())
+	paths, err := js.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{""}, paths)
+
+	data := []byte(`{"theme": "dark", "limits": {"a": 1, "b": 2}}`)
+	assert.NoError(t, js.Scan(data))
+	data[2] = 'X' // the driver reuses the buffer
+	assert.Equal(t, `{"theme": "dark", "limits": {"a": 1, "b": 2}}`, string(js.Original()))
+	assert.False(t, js.Changed(), "the formatting is ignored")
+	paths, err = js.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+
+	js.Data.Limits["a"] = 10
+	delete(js.Data.Limits, "b")
+	js.Data.Tags = []string{"x"}
+	assert.True(t, js.Changed())
+	paths, err = js.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/limits/a", "/limits/b", "/tags"}, paths)
+
+	patch, err := js.Patch()
+	assert.NoError(t, err)
+	res, err := patch.Apply(js.Original())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"theme":"dark","limits":{"a":10},"tags":["x"]}`, string(res))
+
+	assert.NoError(t, js.ResetChanges())
+	assert.False(t, js.Changed())
+
+	assert.Error(t, js.Scan(`{"theme":1}`))
+	assert.Nil(t, js.Original(), "the failed scan is not tracked")
+}
+
+func TestNullableTrackedJSON(t *testing.T) {
+	var js NullableTrackedJSON[patchTestSettings]
+	assert.NoError(t, js.Scan(nil))
+	assert.Equal(t, "null", string(js.Original()))
+	assert.False(t, js.Changed())
+
+	js.Data = &patchTestSettings{Theme: "dark"}
+	assert.True(t, js.Changed())
+	paths, err := js.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{""}, paths)
+
+	assert.NoError(t, js.Scan(`{"theme":"dark"}`))
+	assert.False(t, js.Changed())
+	js.Data.Theme = "light"
+	paths, err = js.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/theme"}, paths)
+
+	js.Data = nil
+	patch, err := js.Patch()
+	assert.NoError(t, err)
+	assert.Equal(t, JSONPatch{{Op: "replace", Path: "", Value: []byte("null")}}, patch)
+}
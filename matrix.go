@@ -0,0 +1,399 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Matrix is the multidimensional array of numbers like `int[][]` or `float8[][][]`
+//
+// The elements are stored in the row-major order and the dimensions keep
+// the length and the lower bound of every dimension of the array.
+type Matrix[T Number] struct {
+	Dims []ArrayDimension
+	Data []T
+}
+
+// NewMatrix creates the two-dimensional matrix from the rows
+func NewMatrix[T Number](rows [][]T) (Matrix[T], error) {
+	dims, data, err := ndArrayFromRows(rows)
+	return Matrix[T]{Dims: dims, Data: data}, err
+}
+
+// MustMatrix creates the two-dimensional matrix from the rows or panics
+func MustMatrix[T Number](rows [][]T) Matrix[T] {
+	m, err := NewMatrix(rows)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Rank returns the number of dimensions
+func (m Matrix[T]) Rank() int { return len(m.Dims) }
+
+// Len returns the total number of elements
+func (m Matrix[T]) Len() int { return len(m.Data) }
+
+// Get returns the element by the index according to the lower bounds
+func (m Matrix[T]) Get(index ...int) (T, bool) {
+	if i, ok := ndArrayOffset(m.Dims, index); ok {
+		return m.Data[i], true
+	}
+	return T(0), false
+}
+
+// Rows returns the rows of the two-dimensional matrix
+func (m Matrix[T]) Rows() [][]T {
+	return ndArrayRows(m.Dims, m.Data)
+}
+
+// Value implements the driver.Valuer interface, T[][] field
+func (m Matrix[T]) Value() (driver.Value, error) {
+	if err := ndArrayValidate(m.Dims, len(m.Data)); err != nil {
+		return nil, err
+	}
+	var buff bytes.Buffer
+	encodeArrayLiteral(&buff, m.Dims, '{', '}', ',', func(buff *bytes.Buffer, i int) {
+		buff.WriteString(formatNumber(m.Data[i]))
+	})
+	return buff.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, T[][] field
+func (m *Matrix[T]) Scan(value any) error {
+	var src string
+	switch v := value.(type) {
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	case nil:
+		return ErrNullValueNotAllowed
+	default:
+		return ErrInvalidScan
+	}
+	dims, elems, err := parseArrayLiteral(src, PostgresArrayFormat)
+	if err != nil {
+		return err
+	}
+	data := make([]T, 0, len(elems))
+	for _, elem := range elems {
+		if elem.null {
+			return ErrNullValueNotAllowed
+		}
+		v, err := parseNumber[T](elem.value)
+		if err != nil {
+			return err
+		}
+		data = append(data, v)
+	}
+	m.Dims, m.Data = dims, data
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler
+func (m Matrix[T]) MarshalJSON() ([]byte, error) {
+	return ndArrayMarshalJSON(m.Dims, m.Data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (m *Matrix[T]) UnmarshalJSON(b []byte) (err error) {
+	m.Dims, m.Data, err = ndArrayUnmarshalJSON[T](b)
+	return err
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// StringMatrix is the multidimensional array of strings like `text[][]`
+type StringMatrix struct {
+	Dims []ArrayDimension
+	Data []string
+}
+
+// NewStringMatrix creates the two-dimensional matrix from the rows
+func NewStringMatrix(rows [][]string) (StringMatrix, error) {
+	dims, data, err := ndArrayFromRows(rows)
+	return StringMatrix{Dims: dims, Data: data}, err
+}
+
+// MustStringMatrix creates the two-dimensional matrix from the rows or panics
+func MustStringMatrix(rows [][]string) StringMatrix {
+	m, err := NewStringMatrix(rows)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Rank returns the number of dimensions
+func (m StringMatrix) Rank() int { return len(m.Dims) }
+
+// Len returns the total number of elements
+func (m StringMatrix) Len() int { return len(m.Data) }
+
+// Get returns the element by the index according to the lower bounds
+func (m StringMatrix) Get(index ...int) (string, bool) {
+	if i, ok := ndArrayOffset(m.Dims, index); ok {
+		return m.Data[i], true
+	}
+	return "", false
+}
+
+// Rows returns the rows of the two-dimensional matrix
+func (m StringMatrix) Rows() [][]string {
+	return ndArrayRows(m.Dims, m.Data)
+}
+
+// Value implements the driver.Valuer interface, text[][] field
+func (m StringMatrix) Value() (driver.Value, error) {
+	if err := ndArrayValidate(m.Dims, len(m.Data)); err != nil {
+		return nil, err
+	}
+	var buff bytes.Buffer
+	encodeArrayLiteral(&buff, m.Dims, '{', '}', ',', func(buff *bytes.Buffer, i int) {
+		appendArrayElement(buff, m.Data[i], ',')
+	})
+	return buff.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, text[][] field
+func (m *StringMatrix) Scan(value any) error {
+	var src string
+	switch v := value.(type) {
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	case nil:
+		return ErrNullValueNotAllowed
+	default:
+		return ErrInvalidScan
+	}
+	dims, elems, err := parseArrayLiteral(src, PostgresArrayFormat)
+	if err != nil {
+		return err
+	}
+	data := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		if elem.null {
+			return ErrNullValueNotAllowed
+		}
+		data = append(data, elem.value)
+	}
+	m.Dims, m.Data = dims, data
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler
+func (m StringMatrix) MarshalJSON() ([]byte, error) {
+	return ndArrayMarshalJSON(m.Dims, m.Data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller
+func (m *StringMatrix) UnmarshalJSON(b []byte) (err error) {
+	m.Dims, m.Data, err = ndArrayUnmarshalJSON[string](b)
+	return err
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// ndArrayJSON is the JSON form of the array with not default lower bounds
+type ndArrayJSON struct {
+	LowerBounds []int           `json:"lower_bounds"`
+	Data        json.RawMessage `json:"data"`
+}
+
+func ndArrayFromRows[T any](rows [][]T) ([]ArrayDimension, []T, error) {
+	if len(rows) == 0 {
+		return nil, []T{}, nil
+	}
+	data := make([]T, 0, len(rows)*len(rows[0]))
+	for _, row := range rows {
+		if len(row) != len(rows[0]) || len(row) == 0 {
+			return nil, nil, fmt.Errorf("%w: rows must have the same non-zero length", ErrInvalidArrayLiteral)
+		}
+		data = append(data, row...)
+	}
+	return []ArrayDimension{
+		{Length: len(rows), LowerBound: 1},
+		{Length: len(rows[0]), LowerBound: 1},
+	}, data, nil
+}
+
+func ndArrayRows[T any](dims []ArrayDimension, data []T) [][]T {
+	switch len(dims) {
+	case 0:
+		return [][]T{}
+	case 1:
+		return [][]T{data}
+	case 2:
+		rows := make([][]T, 0, dims[0].Length)
+		for i := 0; i+dims[1].Length <= len(data); i += dims[1].Length {
+			rows = append(rows, data[i:i+dims[1].Length])
+		}
+		return rows
+	}
+	return nil
+}
+
+func ndArrayOffset(dims []ArrayDimension, index []int) (int, bool) {
+	if len(index) != len(dims) || len(dims) == 0 {
+		return 0, false
+	}
+	offset := 0
+	for i, dim := range dims {
+		idx := index[i] - dim.LowerBound
+		if idx < 0 || idx >= dim.Length {
+			return 0, false
+		}
+		offset = offset*dim.Length + idx
+	}
+	return offset, true
+}
+
+func ndArrayValidate(dims []ArrayDimension, count int) error {
+	total := 0
+	if len(dims) > 0 {
+		total = 1
+		for _, dim := range dims {
+			total *= dim.Length
+		}
+	}
+	if total != count {
+		return fmt.Errorf("%w: dimensions define %d elements, got %d", ErrInvalidArrayLiteral, total, count)
+	}
+	return nil
+}
+
+func ndArrayMarshalJSON[T any](dims []ArrayDimension, data []T) ([]byte, error) {
+	if err := ndArrayValidate(dims, len(data)); err != nil {
+		return nil, err
+	}
+	var (
+		buff   bytes.Buffer
+		offset int
+		err    error
+	)
+	var writeLevel func(depth int)
+	writeLevel = func(depth int) {
+		buff.WriteByte('[')
+		for i := 0; i < dims[depth].Length && err == nil; i++ {
+			if i > 0 {
+				buff.WriteByte(',')
+			}
+			if depth < len(dims)-1 {
+				writeLevel(depth + 1)
+				continue
+			}
+			var item []byte
+			if item, err = json.Marshal(data[offset]); err == nil {
+				buff.Write(item)
+				offset++
+			}
+		}
+		buff.WriteByte(']')
+	}
+	if len(dims) == 0 {
+		buff.WriteString("[]")
+	} else {
+		writeLevel(0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	lowerBounds := make([]int, len(dims))
+	isDefault := true
+	for i, dim := range dims {
+		lowerBounds[i] = dim.LowerBound
+		isDefault = isDefault && dim.LowerBound == 1
+	}
+	if isDefault {
+		return buff.Bytes(), nil
+	}
+	return json.Marshal(ndArrayJSON{LowerBounds: lowerBounds, Data: buff.Bytes()})
+}
+
+func ndArrayUnmarshalJSON[T any](b []byte) ([]ArrayDimension, []T, error) {
+	b = bytes.TrimSpace(b)
+	var lowerBounds []int
+	if len(b) > 0 && b[0] == '{' {
+		var obj ndArrayJSON
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return nil, nil, err
+		}
+		lowerBounds, b = obj.LowerBounds, obj.Data
+	}
+	var (
+		lengths   []int
+		leafDepth = -1
+		data      = []T{}
+	)
+	var walk func(raw json.RawMessage, depth int) error
+	walk = func(raw json.RawMessage, depth int) error {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		if len(items) == 0 && depth > 0 {
+			return fmt.Errorf("%w: empty sub-array", ErrInvalidArrayLiteral)
+		}
+		for _, item := range items {
+			item = bytes.TrimSpace(item)
+			if len(item) > 0 && item[0] == '[' {
+				if leafDepth >= 0 && depth >= leafDepth {
+					return fmt.Errorf("%w: unexpected sub-array", ErrInvalidArrayLiteral)
+				}
+				if err := walk(item, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				return fmt.Errorf("%w: multidimensional arrays must have sub-arrays with matching dimensions", ErrInvalidArrayLiteral)
+			}
+			var v T
+			if err := json.Unmarshal(item, &v); err != nil {
+				return err
+			}
+			data = append(data, v)
+		}
+		for len(lengths) <= depth {
+			lengths = append(lengths, -1)
+		}
+		if lengths[depth] < 0 {
+			lengths[depth] = len(items)
+		} else if lengths[depth] != len(items) {
+			return fmt.Errorf("%w: multidimensional arrays must have sub-arrays with matching dimensions", ErrInvalidArrayLiteral)
+		}
+		return nil
+	}
+	if err := walk(b, 0); err != nil {
+		return nil, nil, err
+	}
+	if len(data) == 0 {
+		if len(lowerBounds) > 0 {
+			return nil, nil, fmt.Errorf("%w: lower bounds of the empty array", ErrInvalidArrayLiteral)
+		}
+		return nil, data, nil
+	}
+	dims := make([]ArrayDimension, len(lengths))
+	for i, l := range lengths {
+		dims[i] = ArrayDimension{Length: l, LowerBound: 1}
+	}
+	if lowerBounds != nil {
+		if len(lowerBounds) != len(dims) {
+			return nil, nil, fmt.Errorf("%w: lower bounds do not match array dimensions", ErrInvalidArrayLiteral)
+		}
+		for i, lb := range lowerBounds {
+			dims[i].LowerBound = lb
+		}
+	}
+	return dims, data, nil
+}
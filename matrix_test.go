@@ -0,0 +1,125 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		var m Matrix[int]
+		if assert.NoError(t, m.Scan("{{1,2,3},{4,5,6}}")) {
+			assert.Equal(t, []ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 3, LowerBound: 1}}, m.Dims)
+			assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, m.Data)
+			assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}}, m.Rows())
+			v, ok := m.Get(2, 3)
+			assert.True(t, ok)
+			assert.Equal(t, 6, v)
+			_, ok = m.Get(0, 1)
+			assert.False(t, ok)
+		}
+		val, err := m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{{1,2,3},{4,5,6}}", val)
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		var m Matrix[float64]
+		if assert.NoError(t, m.Scan("[0:1][-1:0]={{1.5,2},{3,4}}")) {
+			assert.Equal(t, []ArrayDimension{{Length: 2, LowerBound: 0}, {Length: 2, LowerBound: -1}}, m.Dims)
+			v, ok := m.Get(0, -1)
+			assert.True(t, ok)
+			assert.Equal(t, 1.5, v)
+		}
+		val, err := m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "[0:1][-1:0]={{1.5,2},{3,4}}", val)
+
+		data, err := json.Marshal(m)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"lower_bounds":[0,-1],"data":[[1.5,2],[3,4]]}`, string(data))
+
+		var m2 Matrix[float64]
+		assert.NoError(t, json.Unmarshal(data, &m2))
+		assert.Equal(t, m, m2)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		m := MustMatrix([][]int64{{1, 2}, {3, 4}})
+		data, err := json.Marshal(m)
+		assert.NoError(t, err)
+		assert.Equal(t, "[[1,2],[3,4]]", string(data))
+
+		var m2 Matrix[int64]
+		assert.NoError(t, json.Unmarshal([]byte("[[[1],[2]],[[3],[4]]]"), &m2))
+		assert.Equal(t, 3, m2.Rank())
+		assert.Equal(t, 4, m2.Len())
+		assert.Error(t, json.Unmarshal([]byte("[[1,2],[3]]"), &m2))
+		assert.Error(t, json.Unmarshal([]byte("[[1,2],3]"), &m2))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var m Matrix[int]
+		assert.NoError(t, m.Scan("{}"))
+		assert.Equal(t, 0, m.Rank())
+		val, err := m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", val)
+		data, err := json.Marshal(m)
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(data))
+
+		// PostgreSQL reads the empty sub-arrays as the empty array
+		assert.NoError(t, m.Scan("{{}}"))
+		assert.Equal(t, 0, m.Rank())
+		val, err = m.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", val)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var m Matrix[int]
+		assert.ErrorIs(t, m.Scan(nil), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, m.Scan("{{1,2},{3}}"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, m.Scan("{{1,2},{}}"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, m.Scan("{{1,2},3}"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, m.Scan("{1,{2}}"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, m.Scan("[1:3]={1,2}"), ErrInvalidArrayLiteral)
+		assert.ErrorIs(t, m.Scan("{{1,NULL}}"), ErrNullValueNotAllowed)
+		_, err := NewMatrix([][]int{{1}, {2, 3}})
+		assert.ErrorIs(t, err, ErrInvalidArrayLiteral)
+	})
+}
+
+func TestStringMatrix(t *testing.T) {
+	var m StringMatrix
+	if assert.NoError(t, m.Scan(`{{"a,b",c},{"{d}","e f"}}`)) {
+		assert.Equal(t, [][]string{{"a,b", "c"}, {"{d}", "e f"}}, m.Rows())
+	}
+	val, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{{"a,b",c},{"{d}","e f"}}`, val)
+
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `[["a,b","c"],["{d}","e f"]]`, string(data))
+
+	var m2 StringMatrix
+	assert.NoError(t, json.Unmarshal(data, &m2))
+	assert.Equal(t, m, m2)
+}
+
+func TestNumberArrayDimensions(t *testing.T) {
+	var arr NumberArray[int]
+	if assert.NoError(t, arr.Scan("[0:2]={1,2,3}")) {
+		assert.Equal(t, NumberArray[int]{1, 2, 3}, arr)
+	}
+	assert.ErrorIs(t, arr.Scan("{{1,2},{3,4}}"), ErrInvalidArrayLiteral)
+
+	var sarr StringArray
+	if assert.NoError(t, sarr.Scan("[1:2]={a,b}")) {
+		assert.Equal(t, StringArray{"a", "b"}, sarr)
+	}
+}
@@ -0,0 +1,93 @@
+package gosql
+
+import (
+	"bytes"
+	"database/sql/driver"
+)
+
+// NullableDuration is the Duration which can be NULL
+type NullableDuration struct {
+	Duration Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+// NewNullableDuration returns the valid duration
+func NewNullableDuration(d Duration) NullableDuration {
+	return NullableDuration{Duration: d, Valid: true}
+}
+
+// NullableDurationFromPtr returns the duration which is NULL if the pointer is nil
+func NullableDurationFromPtr(d *Duration) NullableDuration {
+	if d == nil {
+		return NullableDuration{}
+	}
+	return NewNullableDuration(*d)
+}
+
+// Ptr returns the pointer to the duration or nil if it's NULL
+func (d NullableDuration) Ptr() *Duration {
+	if !d.Valid {
+		return nil
+	}
+	v := d.Duration
+	return &v
+}
+
+// DurationOr returns the duration or default value if it's NULL
+func (d NullableDuration) DurationOr(def Duration) Duration {
+	if !d.Valid {
+		return def
+	}
+	return d.Duration
+}
+
+// String implements the Stringer interface, returns `null` for the NULL value
+func (d NullableDuration) String() string {
+	if !d.Valid {
+		return "null"
+	}
+	return d.Duration.String()
+}
+
+// Value implements the driver Valuer interface.
+// The output format is defined by SetDurationFormatter.
+func (d NullableDuration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Duration.Value()
+}
+
+// Scan implements the Scanner interface, accepts the same values as Duration and NULL
+func (d *NullableDuration) Scan(value any) error {
+	if value == nil {
+		*d = NullableDuration{}
+		return nil
+	}
+	if err := d.Duration.Scan(value); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d NullableDuration) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return d.Duration.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *NullableDuration) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = NullableDuration{}
+		return nil
+	}
+	if err := d.Duration.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
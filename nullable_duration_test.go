@@ -0,0 +1,52 @@
+package gosql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullableDuration(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		d := NewNullableDuration(Hour)
+		assert.NoError(t, d.Scan(nil))
+		assert.False(t, d.Valid)
+		assert.Nil(t, d.Ptr())
+		assert.Equal(t, Minute, d.DurationOr(Minute))
+
+		assert.NoError(t, d.Scan("1 day 02:00:00"))
+		assert.True(t, d.Valid)
+		assert.Equal(t, Day+2*Hour, d.Duration)
+		assert.Equal(t, Day+2*Hour, *d.Ptr())
+
+		assert.NoError(t, d.Scan(int64(Second)))
+		assert.Equal(t, NewNullableDuration(Second), d)
+
+		assert.Error(t, d.Scan("invalid"))
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := NullableDuration{}.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+
+		v, err = NewNullableDuration(Hour).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "1h0m0s", v)
+		assert.Equal(t, NullableDuration{}, NullableDurationFromPtr(nil))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var v struct{ Timeout NullableDuration }
+		assert.NoError(t, json.Unmarshal([]byte(`{"Timeout":"1h"}`), &v))
+		assert.Equal(t, NewNullableDuration(Hour), v.Timeout)
+		assert.NoError(t, json.Unmarshal([]byte(`{"Timeout":null}`), &v))
+		assert.False(t, v.Timeout.Valid)
+
+		data, err := json.Marshal(v)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Timeout":null}`, string(data))
+		assert.Equal(t, "null", v.Timeout.String())
+	})
+}
@@ -8,7 +8,6 @@ package gosql
 import (
 	"bytes"
 	"database/sql/driver"
-	"encoding/json"
 )
 
 // NullableJSON field
@@ -71,7 +70,7 @@ func (f *NullableJSON[T]) SetValue(value any) error {
 	case []byte:
 		return f.UnmarshalJSON(vl)
 	default:
-		data, err := json.Marshal(value)
+		data, err := jsonCodecOf[T]().Marshal(value)
 		if err != nil {
 			return err
 		}
@@ -110,7 +109,7 @@ func (f NullableJSON[T]) MarshalJSON() ([]byte, error) {
 	if f.Data == nil {
 		return []byte("null"), nil
 	}
-	return json.Marshal(f.Data)
+	return jsonCodecOf[T]().Marshal(f.Data)
 }
 
 // UnmarshalJSON implements the json.Unmarshaller
@@ -123,7 +122,7 @@ func (f *NullableJSON[T]) UnmarshalJSON(data []byte) error {
 	if data = bytes.TrimSpace(data); len(data) == 0 {
 		return nil
 	}
-	err := json.Unmarshal(data, target)
+	err := unmarshalJSONData[T](data, target)
 	if err == nil {
 		f.Data = target
 	}
@@ -68,6 +68,29 @@ func (f *NullableNumberArray[T]) DecodeValue(v any) error {
 	return nil
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array,
+// nil array is encoded as nil
+func (f NullableNumberArray[T]) MarshalBinary() ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return ArrayNumberEncodeBinary(f)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *NullableNumberArray[T]) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		*f = nil
+		return nil
+	}
+	list, err := ArrayNumberDecodeBinary[T](data)
+	if err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
 // Sort ints array
 func (f NullableNumberArray[T]) Sort() NullableNumberArray[T] {
 	sort.Sort(f)
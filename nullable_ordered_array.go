@@ -43,6 +43,17 @@ func (f *NullableOrderedNumberArray[T]) DecodeValue(v any) error {
 	return (*NullableNumberArray[T])(f).DecodeValue(v)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array,
+// nil array is encoded as nil
+func (f NullableOrderedNumberArray[T]) MarshalBinary() ([]byte, error) {
+	return NullableNumberArray[T](f).MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *NullableOrderedNumberArray[T]) UnmarshalBinary(data []byte) error {
+	return (*NullableNumberArray[T])(f).UnmarshalBinary(data)
+}
+
 // Sort ints array
 func (f NullableOrderedNumberArray[T]) Sort() NullableOrderedNumberArray[T] {
 	sort.Sort(f)
@@ -48,6 +48,19 @@ func (f *NumberArray[T]) DecodeValue(v any) error {
 	return (*NullableNumberArray[T])(f).DecodeValue(v)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array
+func (f NumberArray[T]) MarshalBinary() ([]byte, error) {
+	return ArrayNumberEncodeBinary(f)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *NumberArray[T]) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableNumberArray[T])(f).UnmarshalBinary(data)
+}
+
 // Sort ints NumberArray
 func (f NumberArray[T]) Sort() NumberArray[T] {
 	sort.Sort(f)
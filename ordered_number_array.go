@@ -45,6 +45,19 @@ func (f *OrderedNumberArray[T]) DecodeValue(v any) error {
 	return (*NullableNumberArray[T])(f).DecodeValue(v)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array
+func (f OrderedNumberArray[T]) MarshalBinary() ([]byte, error) {
+	return ArrayNumberEncodeBinary(f)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *OrderedNumberArray[T]) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableNumberArray[T])(f).UnmarshalBinary(data)
+}
+
 // Sort ints array
 func (f OrderedNumberArray[T]) Sort() OrderedNumberArray[T] {
 	sort.Sort(f)
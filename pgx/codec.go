@@ -0,0 +1,135 @@
+// Package pgx provides the native pgx v5 codecs of the gosql types
+// for the text and binary wire formats of PostgreSQL.
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		gosqlpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+package pgx
+
+import (
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+// codecFamily is the group of gosql types supported by the wrapped PostgreSQL type
+type codecFamily int
+
+const (
+	familyArray codecFamily = iota
+	familyJSON
+	familyInterval
+	familyChar
+)
+
+// wrappedTypes is the list of PostgreSQL types which codecs are wrapped by Register
+var wrappedTypes = []struct {
+	name   string
+	family codecFamily
+}{
+	{"_int2", familyArray},
+	{"_int4", familyArray},
+	{"_int8", familyArray},
+	{"_float4", familyArray},
+	{"_float8", familyArray},
+	{"_numeric", familyArray},
+	{"_text", familyArray},
+	{"_varchar", familyArray},
+	{"_bpchar", familyArray},
+	{"json", familyJSON},
+	{"jsonb", familyJSON},
+	{"interval", familyInterval},
+	{"char", familyChar},
+	{"bpchar", familyChar},
+	{"text", familyChar},
+	{"varchar", familyChar},
+}
+
+// Register wraps the codecs of the array, JSON, interval and character types of the map
+// to encode and decode gosql types natively. Other values are processed by the original codecs.
+//
+// Supported types: NumberArray, NullableNumberArray, OrderedNumberArray, NullableOrderedNumberArray,
+// StringArray, NullableStringArray, JSON, NullableJSON, TrackedJSON, NullableTrackedJSON, LazyJSON,
+// JSONArray, NullableJSONArray, Duration, NullableDuration, Interval and Char.
+func Register(m *pgtype.Map) {
+	for _, wt := range wrappedTypes {
+		t, ok := m.TypeForName(wt.name)
+		if !ok {
+			continue
+		}
+		if _, ok := t.Codec.(*codec); ok {
+			continue
+		}
+		m.RegisterType(&pgtype.Type{Name: t.Name, OID: t.OID, Codec: &codec{Codec: t.Codec, family: wt.family}})
+	}
+	m.RegisterDefaultPgType(gosql.Duration(0), "interval")
+	m.RegisterDefaultPgType(gosql.NullableDuration{}, "interval")
+	m.RegisterDefaultPgType(gosql.Interval{}, "interval")
+	m.RegisterDefaultPgType(gosql.Char(0), "bpchar")
+	m.RegisterDefaultPgType(gosql.StringArray(nil), "_text")
+	m.RegisterDefaultPgType(gosql.NullableStringArray(nil), "_text")
+}
+
+// codec wraps the original codec of the PostgreSQL type
+type codec struct {
+	pgtype.Codec
+	family codecFamily
+}
+
+func (c *codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if conv := converterOf(reflect.TypeOf(value), c.family); conv != nil {
+		return &encodePlan{m: m, oid: oid, format: format, conv: conv}
+	}
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+func (c *codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if t := reflect.TypeOf(target); t != nil && t.Kind() == reflect.Pointer {
+		if conv := converterOf(t.Elem(), c.family); conv != nil {
+			return &scanPlan{m: m, oid: oid, format: format, conv: conv}
+		}
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+// encodePlan converts the gosql value to the value supported by the original codec
+type encodePlan struct {
+	m      *pgtype.Map
+	oid    uint32
+	format int16
+	conv   converter
+}
+
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	wire, err := p.conv.encode(value, p.oid)
+	if err != nil || wire == nil {
+		return nil, err
+	}
+	plan := p.m.PlanEncode(p.oid, p.format, wire)
+	if plan == nil {
+		return nil, errUnsupportedValue
+	}
+	return plan.Encode(wire, buf)
+}
+
+// scanPlan scans the database value by the original codec and converts it to the gosql value
+type scanPlan struct {
+	m      *pgtype.Map
+	oid    uint32
+	format int16
+	conv   converter
+}
+
+func (p *scanPlan) Scan(src []byte, target any) error {
+	if src == nil {
+		return p.conv.null(target)
+	}
+	wire := p.conv.wire(p.oid)
+	if err := p.m.PlanScan(p.oid, p.format, wire).Scan(src, wire); err != nil {
+		return err
+	}
+	return p.conv.decode(wire, target)
+}
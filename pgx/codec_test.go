@@ -0,0 +1,220 @@
+package pgx
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	Register(m) // second call must not wrap codecs twice
+	return m
+}
+
+func fixture(s string) []byte {
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestCodecBinary(t *testing.T) {
+	type doc struct {
+		A int `json:"a"`
+	}
+	tests := []struct {
+		name   string
+		oid    uint32
+		value  any
+		data   []byte
+		target func() any
+	}{
+		{
+			name:  "int8[]",
+			oid:   pgtype.Int8ArrayOID,
+			value: gosql.NumberArray[int64]{1, 2},
+			data: fixture("00000001 00000000 00000014 00000002 00000001" +
+				"00000008 0000000000000001 00000008 0000000000000002"),
+			target: func() any { return new(gosql.NumberArray[int64]) },
+		},
+		{
+			name:   "int4[]",
+			oid:    pgtype.Int4ArrayOID,
+			value:  gosql.NullableOrderedNumberArray[int32]{-1},
+			data:   fixture("00000001 00000000 00000017 00000001 00000001 00000004 ffffffff"),
+			target: func() any { return new(gosql.NullableOrderedNumberArray[int32]) },
+		},
+		{
+			name:   "float8[]",
+			oid:    pgtype.Float8ArrayOID,
+			value:  gosql.NumberArray[float64]{1.5},
+			data:   fixture("00000001 00000000 000002bd 00000001 00000001 00000008 3ff8000000000000"),
+			target: func() any { return new(gosql.NumberArray[float64]) },
+		},
+		{
+			name:  "text[]",
+			oid:   pgtype.TextArrayOID,
+			value: gosql.StringArray{"a", "b c"},
+			data: fixture("00000001 00000000 00000019 00000002 00000001" +
+				"00000001 61 00000003 622063"),
+			target: func() any { return new(gosql.StringArray) },
+		},
+		{
+			name:   "jsonb",
+			oid:    pgtype.JSONBOID,
+			value:  gosql.JSON[doc]{Data: doc{A: 1}},
+			data:   append([]byte{1}, `{"a":1}`...),
+			target: func() any { return new(gosql.JSON[doc]) },
+		},
+		{
+			name:   "json",
+			oid:    pgtype.JSONOID,
+			value:  gosql.NullableJSON[doc]{Data: &doc{A: 2}},
+			data:   []byte(`{"a":2}`),
+			target: func() any { return new(gosql.NullableJSON[doc]) },
+		},
+		{
+			name:   "interval:duration",
+			oid:    pgtype.IntervalOID,
+			value:  gosql.Duration(26 * gosql.Hour),
+			data:   fixture("00000015cafea800 00000000 00000000"),
+			target: func() any { return new(gosql.Duration) },
+		},
+		{
+			name:   "interval",
+			oid:    pgtype.IntervalOID,
+			value:  gosql.Interval{Months: 14, Days: 1, Microseconds: 7200e6},
+			data:   fixture("00000001ad274800 00000001 0000000e"),
+			target: func() any { return new(gosql.Interval) },
+		},
+		{
+			name:   "char",
+			oid:    pgtype.QCharOID,
+			value:  gosql.Char('x'),
+			data:   []byte{'x'},
+			target: func() any { return new(gosql.Char) },
+		},
+		{
+			name:   "bpchar",
+			oid:    pgtype.BPCharOID,
+			value:  gosql.Char('é'),
+			data:   []byte("é"),
+			target: func() any { return new(gosql.Char) },
+		},
+	}
+	m := newMap()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := m.Encode(test.oid, pgtype.BinaryFormatCode, test.value, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.data, data)
+
+			target := test.target()
+			assert.NoError(t, m.Scan(test.oid, pgtype.BinaryFormatCode, test.data, target))
+			assert.Equal(t, test.value, reflectElem(target))
+		})
+	}
+}
+
+func TestCodecText(t *testing.T) {
+	m := newMap()
+
+	data, err := m.Encode(pgtype.Int8ArrayOID, pgtype.TextFormatCode, gosql.NumberArray[int64]{1, 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "{1,2}", string(data))
+
+	var arr gosql.NullableStringArray
+	assert.NoError(t, m.Scan(pgtype.TextArrayOID, pgtype.TextFormatCode, []byte(`{a,"b c"}`), &arr))
+	assert.Equal(t, gosql.NullableStringArray{"a", "b c"}, arr)
+	assert.Error(t, m.Scan(pgtype.TextArrayOID, pgtype.TextFormatCode, []byte(`{a,NULL}`), &arr))
+
+	var d gosql.Duration
+	assert.NoError(t, m.Scan(pgtype.IntervalOID, pgtype.TextFormatCode, []byte("1 day 02:00:00"), &d))
+	assert.Equal(t, gosql.Duration(26*gosql.Hour), d)
+
+	data, err = m.Encode(pgtype.IntervalOID, pgtype.TextFormatCode, gosql.Duration(90*gosql.Second), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, m.Scan(pgtype.IntervalOID, pgtype.TextFormatCode, data, &d))
+	assert.Equal(t, gosql.Duration(90*gosql.Second), d)
+
+	var js gosql.JSON[map[string]int]
+	assert.NoError(t, m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"a":1}`), &js))
+	assert.Equal(t, map[string]int{"a": 1}, js.Data)
+
+	var tracked gosql.TrackedJSON[map[string]int]
+	assert.NoError(t, m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, append([]byte{1}, `{"a":1}`...), &tracked))
+	assert.Equal(t, `{"a":1}`, string(tracked.Original()))
+	assert.False(t, tracked.Changed())
+
+	var lazy gosql.LazyJSON[map[string]int]
+	assert.NoError(t, m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, append([]byte{1}, `{"a": 2}`...), &lazy))
+	assert.Equal(t, `{"a": 2}`, string(lazy.Raw()))
+	data, err = m.Encode(pgtype.JSONBOID, pgtype.BinaryFormatCode, lazy, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{1}, `{"a": 2}`...), data)
+}
+
+func TestCodecNull(t *testing.T) {
+	m := newMap()
+
+	data, err := m.Encode(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, gosql.NullableNumberArray[int64](nil), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = m.Encode(pgtype.Int8ArrayOID, pgtype.TextFormatCode, gosql.NumberArray[int64](nil), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+
+	data, err = m.Encode(pgtype.JSONBOID, pgtype.BinaryFormatCode, gosql.NullableJSON[int]{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = m.Encode(pgtype.IntervalOID, pgtype.BinaryFormatCode, gosql.NullableDuration{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	nums := gosql.NullableNumberArray[int64]{1}
+	assert.NoError(t, m.Scan(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, nil, &nums))
+	assert.Nil(t, nums)
+
+	nd := gosql.NewNullableDuration(gosql.Second)
+	assert.NoError(t, m.Scan(pgtype.IntervalOID, pgtype.BinaryFormatCode, nil, &nd))
+	assert.False(t, nd.Valid)
+
+	var arr gosql.NumberArray[int64]
+	assert.ErrorIs(t, m.Scan(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, nil, &arr), gosql.ErrNullValueNotAllowed)
+	var d gosql.Duration
+	assert.ErrorIs(t, m.Scan(pgtype.IntervalOID, pgtype.BinaryFormatCode, nil, &d), gosql.ErrNullValueNotAllowed)
+	var js gosql.JSON[int]
+	assert.ErrorIs(t, m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, nil, &js), gosql.ErrNullValueNotAllowed)
+}
+
+func TestCodecOriginalTypes(t *testing.T) {
+	m := newMap()
+
+	data, err := m.Encode(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, []int64{1, 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, fixture("00000001 00000000 00000014 00000002 00000001"+
+		"00000008 0000000000000001 00000008 0000000000000002"), data)
+
+	var s string
+	assert.NoError(t, m.Scan(pgtype.TextOID, pgtype.TextFormatCode, []byte("abc"), &s))
+	assert.Equal(t, "abc", s)
+
+	typ, ok := m.TypeForValue(gosql.Duration(0))
+	assert.True(t, ok)
+	assert.Equal(t, uint32(pgtype.IntervalOID), typ.OID)
+}
+
+func reflectElem(ptr any) any {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
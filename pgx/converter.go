@@ -0,0 +1,259 @@
+package pgx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/geniusrabbit/gosql/v2"
+)
+
+var errUnsupportedValue = errors.New("unsupported value")
+
+// converter maps the gosql value to the value supported by the original pgtype codec
+type converter interface {
+	// encode returns the value for the original codec, nil is the NULL value
+	encode(value any, oid uint32) (any, error)
+
+	// wire returns the pointer to the value scanned by the original codec
+	wire(oid uint32) any
+
+	// decode sets the target from the scanned wire value
+	decode(wire, target any) error
+
+	// null sets the target from the NULL value
+	null(target any) error
+}
+
+var (
+	gosqlPkgPath = reflect.TypeOf(gosql.Char(0)).PkgPath()
+
+	// genericFamilies maps the generic gosql types to the codec family
+	genericFamilies = map[string]codecFamily{
+		"NumberArray":                familyArray,
+		"NullableNumberArray":        familyArray,
+		"OrderedNumberArray":         familyArray,
+		"NullableOrderedNumberArray": familyArray,
+		"StringArray":                familyArray,
+		"NullableStringArray":        familyArray,
+		"JSON":                       familyJSON,
+		"NullableJSON":               familyJSON,
+		"TrackedJSON":                familyJSON,
+		"NullableTrackedJSON":        familyJSON,
+		"LazyJSON":                   familyJSON,
+		"JSONArray":                  familyJSON,
+		"NullableJSONArray":          familyJSON,
+	}
+
+	converters sync.Map // reflect.Type -> converter or nil
+)
+
+type noConverter struct{}
+
+// converterOf returns the converter of the type if it belongs to the family
+func converterOf(t reflect.Type, family codecFamily) converter {
+	if t == nil || t.PkgPath() != gosqlPkgPath {
+		return nil
+	}
+	conv, ok := converters.Load(t)
+	if !ok {
+		conv, _ = converters.LoadOrStore(t, newConverter(t))
+	}
+	if c, ok := conv.(familyConverter); ok && c.family == family {
+		return c.converter
+	}
+	return nil
+}
+
+type familyConverter struct {
+	converter
+	family codecFamily
+}
+
+func newConverter(t reflect.Type) any {
+	switch t {
+	case reflect.TypeOf(gosql.Duration(0)):
+		return familyConverter{durationConverter{}, familyInterval}
+	case reflect.TypeOf(gosql.NullableDuration{}):
+		return familyConverter{nullableDurationConverter{}, familyInterval}
+	case reflect.TypeOf(gosql.Interval{}):
+		return familyConverter{intervalConverter{}, familyInterval}
+	case reflect.TypeOf(gosql.Char(0)):
+		return familyConverter{charConverter{}, familyChar}
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	family, ok := genericFamilies[name]
+	if !ok {
+		return noConverter{}
+	}
+	nullable := strings.HasPrefix(name, "Nullable")
+	if family == familyJSON {
+		return familyConverter{jsonConverter{nullable: nullable}, family}
+	}
+	return familyConverter{sliceConverter{typ: t, wireType: reflect.SliceOf(t.Elem()), nullable: nullable}, family}
+}
+
+// sliceConverter converts the gosql array to the plain slice of elements
+type sliceConverter struct {
+	typ      reflect.Type
+	wireType reflect.Type
+	nullable bool
+}
+
+func (c sliceConverter) encode(value any, _ uint32) (any, error) {
+	v := reflect.ValueOf(value)
+	if v.IsNil() {
+		if c.nullable {
+			return nil, nil
+		}
+		return reflect.MakeSlice(c.wireType, 0, 0).Interface(), nil
+	}
+	return v.Convert(c.wireType).Interface(), nil
+}
+
+func (c sliceConverter) wire(_ uint32) any {
+	return reflect.New(c.wireType).Interface()
+}
+
+func (c sliceConverter) decode(wire, target any) error {
+	reflect.ValueOf(target).Elem().Set(reflect.ValueOf(wire).Elem().Convert(c.typ))
+	return nil
+}
+
+func (c sliceConverter) null(target any) error {
+	if !c.nullable {
+		return gosql.ErrNullValueNotAllowed
+	}
+	reflect.ValueOf(target).Elem().SetZero()
+	return nil
+}
+
+// jsonConverter passes the JSON document of the driver.Valuer and sql.Scanner as bytes,
+// so the jsonb version header is processed by the original codec
+type jsonConverter struct {
+	nullable bool
+}
+
+func (c jsonConverter) encode(value any, _ uint32) (any, error) {
+	v, err := value.(driver.Valuer).Value()
+	if err != nil || v == nil {
+		return nil, err
+	}
+	switch data := v.(type) {
+	case string:
+		if c.nullable && data == "null" {
+			return nil, nil
+		}
+		return []byte(data), nil
+	case []byte:
+		if c.nullable && string(data) == "null" {
+			return nil, nil
+		}
+		return data, nil
+	}
+	return nil, errUnsupportedValue
+}
+
+func (c jsonConverter) wire(_ uint32) any {
+	return new([]byte)
+}
+
+func (c jsonConverter) decode(wire, target any) error {
+	return target.(sql.Scanner).Scan(*wire.(*[]byte))
+}
+
+func (c jsonConverter) null(target any) error {
+	return target.(sql.Scanner).Scan(nil)
+}
+
+// durationConverter converts the Duration to the interval of microseconds,
+// months and days of the scanned interval are converted like Interval.Duration
+type durationConverter struct{}
+
+func (durationConverter) encode(value any, _ uint32) (any, error) {
+	return pgtype.Interval{Microseconds: value.(gosql.Duration).Microseconds(), Valid: true}, nil
+}
+
+func (durationConverter) wire(_ uint32) any {
+	return new(pgtype.Interval)
+}
+
+func (durationConverter) decode(wire, target any) error {
+	*target.(*gosql.Duration) = intervalOf(*wire.(*pgtype.Interval)).Duration()
+	return nil
+}
+
+func (durationConverter) null(_ any) error {
+	return gosql.ErrNullValueNotAllowed
+}
+
+type nullableDurationConverter struct{ durationConverter }
+
+func (nullableDurationConverter) encode(value any, _ uint32) (any, error) {
+	v := value.(gosql.NullableDuration)
+	if !v.Valid {
+		return nil, nil
+	}
+	return pgtype.Interval{Microseconds: v.Duration.Microseconds(), Valid: true}, nil
+}
+
+func (nullableDurationConverter) decode(wire, target any) error {
+	*target.(*gosql.NullableDuration) = gosql.NewNullableDuration(intervalOf(*wire.(*pgtype.Interval)).Duration())
+	return nil
+}
+
+func (nullableDurationConverter) null(target any) error {
+	*target.(*gosql.NullableDuration) = gosql.NullableDuration{}
+	return nil
+}
+
+// intervalConverter converts the Interval field by field
+type intervalConverter struct{ durationConverter }
+
+func (intervalConverter) encode(value any, _ uint32) (any, error) {
+	v := value.(gosql.Interval)
+	return pgtype.Interval{Months: v.Months, Days: v.Days, Microseconds: v.Microseconds, Valid: true}, nil
+}
+
+func (intervalConverter) decode(wire, target any) error {
+	*target.(*gosql.Interval) = intervalOf(*wire.(*pgtype.Interval))
+	return nil
+}
+
+// charConverter converts the Char to the rune of the "char" type or to the string of text types
+type charConverter struct{}
+
+func (charConverter) encode(value any, oid uint32) (any, error) {
+	if oid == pgtype.QCharOID {
+		return rune(value.(gosql.Char)), nil
+	}
+	return value.(gosql.Char).Value()
+}
+
+func (charConverter) wire(oid uint32) any {
+	if oid == pgtype.QCharOID {
+		return new(rune)
+	}
+	return new(string)
+}
+
+func (charConverter) decode(wire, target any) error {
+	if r, ok := wire.(*rune); ok {
+		*target.(*gosql.Char) = gosql.Char(*r)
+		return nil
+	}
+	return target.(*gosql.Char).Scan(*wire.(*string))
+}
+
+func (charConverter) null(_ any) error {
+	return gosql.ErrNullValueNotAllowed
+}
+
+func intervalOf(v pgtype.Interval) gosql.Interval {
+	return gosql.Interval{Months: v.Months, Days: v.Days, Microseconds: v.Microseconds}
+}
@@ -0,0 +1,21 @@
+module github.com/geniusrabbit/gosql/pgx
+
+go 1.20
+
+require (
+	github.com/geniusrabbit/gosql/v2 v2.4.0
+	github.com/jackc/pgx/v5 v5.5.5
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The local copy is used for development, the release requires the tagged v2.4.0
+// of the root module with the new types, see "Releasing" in README.md
+replace github.com/geniusrabbit/gosql/v2 => ../
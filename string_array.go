@@ -34,20 +34,20 @@ func (f NullableStringArray) Value() (driver.Value, error) {
 	if f == nil {
 		return nil, nil
 	}
-	return encodeNullableStringArray('{', '}', '"', `""`, f).String(), nil
+	return encodeNullableStringArray('{', '}', ',', f).String(), nil
 }
 
 // Scan implements the driver.Valuer interface, []string field
-func (f *NullableStringArray) Scan(value any) error {
+func (f *NullableStringArray) Scan(value any) (err error) {
 	switch val := value.(type) {
 	case []byte:
-		*f = decodeNullableStringArray(string(val), '{', '}', '"', `""`)
+		*f, err = decodeNullableStringArray(string(val), '{', '}', ',')
 	case string:
-		*f = decodeNullableStringArray(val, '{', '}', '"', `""`)
+		*f, err = decodeNullableStringArray(val, '{', '}', ',')
 	case nil:
 		*f = nil
 	}
-	return nil
+	return err
 }
 
 // MarshalJSON implements the json.Marshaler
@@ -87,6 +87,29 @@ func (f *NullableStringArray) DecodeValue(v any) error {
 	return nil
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array,
+// nil array is encoded as nil
+func (f NullableStringArray) MarshalBinary() ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return ArrayStringEncodeBinary(f), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *NullableStringArray) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		*f = nil
+		return nil
+	}
+	list, err := ArrayStringDecodeBinary(data)
+	if err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
 // Len of array
 func (f NullableStringArray) Len() int {
 	return len(f)
@@ -169,6 +192,19 @@ func (f *StringArray) DecodeValue(v any) error {
 	return (*NullableStringArray)(f).DecodeValue(v)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler, returns the PostgreSQL binary array
+func (f StringArray) MarshalBinary() ([]byte, error) {
+	return ArrayStringEncodeBinary(f), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler, decodes the PostgreSQL binary array
+func (f *StringArray) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return ErrNullValueNotAllowed
+	}
+	return (*NullableStringArray)(f).UnmarshalBinary(data)
+}
+
 // Len of array
 func (f StringArray) Len() int {
 	return len(f)
@@ -188,48 +224,14 @@ func (f StringArray) OneOf(vals []string) bool {
 /// Helpers
 ///////////////////////////////////////////////////////////////////////////////
 
-func decodeNullableStringArray(arrSrc string, begin, end, border byte, escape string) []string {
+func decodeNullableStringArray(arrSrc string, begin, end, delim byte) ([]string, error) {
 	if strings.EqualFold(arrSrc, "null") {
-		return nil
-	}
-	if arrSrc == string([]byte{begin, end}) {
-		return []string{}
-	}
-	arr := strings.Split(strings.Trim(arrSrc, "{}[]"), ",")
-	for i, val := range arr {
-		val = strings.TrimSpace(val)
-		if val == string([]byte{border, border}) {
-			arr[i] = ""
-		} else {
-			if strings.HasPrefix(val, `"`) && strings.HasSuffix(val, `"`) {
-				val = val[1 : len(val)-1]
-			}
-			arr[i] = strings.ReplaceAll(val, escape, string(border))
-		}
+		return nil, nil
 	}
-	return arr
+	return decodeArray[string, StringCodec](arrSrc, postgresArrayFormatWith(begin, end, delim))
 }
 
-func encodeNullableStringArray(begin, end, border byte, escape string, arr []string) *bytes.Buffer {
-	var buff bytes.Buffer
-	buff.WriteByte(begin)
-
-	for i, v := range arr {
-		if i > 0 {
-			buff.WriteByte(',')
-		}
-		if byte(0) != border {
-			buff.WriteByte(border)
-			if escape != "" {
-				v = strings.ReplaceAll(v, string(border), escape)
-			}
-		}
-		buff.WriteString(v)
-		if byte(0) != border {
-			buff.WriteByte(border)
-		}
-	}
-
-	buff.WriteByte(end)
-	return &buff
+func encodeNullableStringArray(begin, end, delim byte, arr []string) *bytes.Buffer {
+	buff, _ := encodeArray[string, StringCodec](postgresArrayFormatWith(begin, end, delim), arr)
+	return buff
 }
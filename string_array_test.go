@@ -3,7 +3,6 @@ package gosql
 import (
 	"encoding/json"
 	"reflect"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,5 +34,64 @@ func TestStringArraySQL(t *testing.T) {
 		"compare scan result")
 	sqlVal, err := arr.Value()
 	assert.NoError(t, err, "encode array")
-	assert.Equal(t, strings.ReplaceAll(sqlStringArray, " ", ""), sqlVal)
+	assert.Equal(t, `{breakfast,consulting,"bar-\"#1\""}`, sqlVal)
+}
+
+func TestStringArrayLiteral(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		target []string
+	}{
+		{"empty", "{}", []string{}},
+		{"empty_sub_arrays", "{{},{{}}}", []string{}},
+		{"spaces", " { a , b c ,d } ", []string{"a", "b c", "d"}},
+		{"quoted_comma", `{"a,b","c"}`, []string{"a,b", "c"}},
+		{"quoted_braces", `{"{x}","}"}`, []string{"{x}", "}"}},
+		{"quoted_spaces", `{" a ",""}`, []string{" a ", ""}},
+		{"escapes", `{"a\"b","c\\d",e\,f}`, []string{`a"b`, `c\d`, "e,f"}},
+		{"quoted_null", `{"NULL",\NULL}`, []string{"NULL", "NULL"}},
+		{"unicode", `{"привет, мир",✓}`, []string{"привет, мир", "✓"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var arr NullableStringArray
+			if assert.NoError(t, arr.Scan(test.src)) {
+				assert.Equal(t, NullableStringArray(test.target), arr)
+			}
+			val, err := arr.Value()
+			if assert.NoError(t, err) {
+				var arr2 NullableStringArray
+				assert.NoError(t, arr2.Scan(val))
+				assert.Equal(t, arr, arr2, "round-trip")
+			}
+		})
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		val, err := NullableStringArray{"a", "b,c", "", "NULL", `q"\`, "x y", "{}"}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{a,"b,c","","NULL","q\"\\","x y","{}"}`, val)
+	})
+
+	t.Run("null_element", func(t *testing.T) {
+		var arr NullableStringArray
+		assert.ErrorIs(t, arr.Scan("{a,NULL}"), ErrNullValueNotAllowed)
+		assert.ErrorIs(t, arr.Scan("{a,null}"), ErrNullValueNotAllowed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, src := range []string{"a,b", "{a,b", `{"a}`, "{a,,b}", "{a}b", `{a"b}`, "{{a}}", "{{},{a}}", "{{},a}"} {
+			var arr NullableStringArray
+			assert.ErrorIs(t, arr.Scan(src), ErrInvalidArrayLiteral, src)
+		}
+	})
+
+	t.Run("custom_delimiter", func(t *testing.T) {
+		arr, err := decodeNullableStringArray(`{a,b;"c;d"}`, '{', '}', ';')
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"a,b", "c;d"}, arr)
+		}
+		assert.Equal(t, `{a,b;"c;d"}`, encodeNullableStringArray('{', '}', ';', arr).String())
+	})
 }
@@ -2,6 +2,7 @@ package gosql
 
 import (
 	"bytes"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,10 @@ type Number interface {
 }
 
 // ArrayNumberDecode decodes array of type int
+//
+// The data can be the PostgreSQL array literal (`{1,2}` or `[0:1]={1,2}`),
+// JSON array (`[1,2]`) or the plain list of values (`1,2`) which is wrapped
+// by begin and end characters.
 func ArrayNumberDecode[T Number](data any, begin, end byte) (result []T, err error) {
 	var arr string
 	switch vdata := data.(type) {
@@ -28,60 +33,53 @@ func ArrayNumberDecode[T Number](data any, begin, end byte) (result []T, err err
 	default:
 		return nil, ErrInvalidScan
 	}
-	if strings.EqualFold(arr, "null") {
+	if arr = strings.TrimSpace(arr); strings.EqualFold(arr, "null") {
 		return nil, nil
 	}
-	if arr == "{}" || arr == "[]" || len(arr) == 0 {
+	if len(arr) == 0 {
 		return []T{}, err
 	}
-	if vals := strings.Split(strings.Trim(arr, "{}[]"), ","); vals != nil {
-		result = make([]T, 0, len(vals))
-		for _, cid := range vals {
-			switch any(T(0)).(type) {
-			case int, int8, int16, int32, int64:
-				if v, err := strconv.ParseInt(strings.Trim(cid, "'\""), 10, 64); err == nil {
-					result = append(result, T(v))
-				} else {
-					return nil, err
-				}
-			case uint, uint8, uint16, uint32, uint64:
-				if v, err := strconv.ParseUint(strings.Trim(cid, "'\""), 10, 64); err == nil {
-					result = append(result, T(v))
-				} else {
-					return nil, err
-				}
-			case float32, float64:
-				if v, err := strconv.ParseFloat(strings.Trim(cid, "'\""), 64); err == nil {
-					switch sv := any(v).(type) {
-					case T: // NOTE: Temporary hack of type casting
-						result = append(result, sv)
-					}
-				} else {
-					return nil, err
-				}
-			}
-		} // end for
+	switch {
+	case arr[0] == '{', arr[0] == '[' && strings.Contains(arr, "="):
+		begin, end = '{', '}'
+	case arr[0] == '[':
+		begin, end = '[', ']'
+	default:
+		arr = string(begin) + arr + string(end)
 	}
-	return result, err
+	return decodeArray[T, NumberCodec[T]](arr, postgresArrayFormatWith(begin, end, ','))
 }
 
 // ArrayEncode encodes array of type int
 func ArrayNumberEncode[T Number](begin, end byte, arr []T) *bytes.Buffer {
-	var buff bytes.Buffer
-	buff.WriteByte(begin)
-	for i, v := range arr {
-		if i > 0 {
-			buff.WriteByte(',')
-		}
-		switch any(v).(type) {
-		case int, int8, int16, int32, int64:
-			buff.WriteString(strconv.FormatInt(int64(v), 10))
-		case uint, uint8, uint16, uint32, uint64:
-			buff.WriteString(strconv.FormatUint(uint64(v), 10))
-		case float32, float64:
-			buff.WriteString(strconv.FormatFloat(float64(v), 'G', -1, 64))
-		}
+	buff, _ := encodeArray[T, NumberCodec[T]](postgresArrayFormatWith(begin, end, ','), arr)
+	return buff
+}
+
+// parseNumber parses the number by the kind of T, so named types like `type ID int64` are supported
+func parseNumber[T Number](s string) (T, error) {
+	s = strings.Trim(s, "'\"")
+	switch t := reflect.TypeOf(T(0)); t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, t.Bits())
+		return T(v), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(s, 10, t.Bits())
+		return T(v), err
+	default:
+		v, err := strconv.ParseFloat(s, 64)
+		return T(v), err
+	}
+}
+
+// formatNumber formats the number by the kind of T
+func formatNumber[T Number](v T) string {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(int64(v), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return strconv.FormatFloat(float64(v), 'G', -1, 64)
 	}
-	buff.WriteByte(end)
-	return &buff
 }